@@ -3,6 +3,7 @@ module github.com/hacomono-lib/go-i18ngen
 go 1.23.2
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/nicksnyder/go-i18n/v2 v2.6.0
 	github.com/spf13/cobra v1.9.1
 	github.com/stretchr/testify v1.10.0
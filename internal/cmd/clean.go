@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCleanCommand creates and returns the clean command
+func NewCleanCommand() *cobra.Command {
+	var (
+		cleanConfigPath string
+		cleanFlags      Flags
+		dryRun          bool
+	)
+
+	cleanCmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove generated files from the output directory",
+		Long: "Remove the files written by generate from the output directory. Only files carrying the\n" +
+			"\"Code generated ... DO NOT EDIT.\" header are removed; any other file aborts the command.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cleanConfigPath)
+			if err != nil {
+				return err
+			}
+			merged := MergeConfig(cfg, &cleanFlags)
+			if merged.OutputDir == "" {
+				return fmt.Errorf("output directory cannot be empty")
+			}
+
+			// Check every per-locale directory before removing anything from one of them
+			dirs := generator.OutputDirs(merged.OutputDir, merged.Locales)
+			for _, dir := range dirs {
+				if _, err := generator.Clean(dir, true); err != nil {
+					return err
+				}
+			}
+			var removed []string
+			for _, dir := range dirs {
+				paths, err := generator.Clean(dir, dryRun)
+				if err != nil {
+					return err
+				}
+				removed = append(removed, paths...)
+			}
+			for _, path := range removed {
+				// The listing is what --dry-run asks for, so --quiet only silences the removals
+				if dryRun {
+					fmt.Fprintln(cmd.OutOrStdout(), "would remove", path)
+					continue
+				}
+				fmt.Fprintln(successOutput(cmd), "removed", path)
+			}
+			return nil
+		},
+	}
+
+	cleanCmd.Flags().StringVarP(&cleanConfigPath, "config", "c", "", configFlagUsage)
+	cleanCmd.Flags().StringVar(&cleanFlags.OutputDir, "output", "", "output directory")
+	cleanCmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the files that would be removed without removing them")
+
+	return cleanCmd
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanCommand(t *testing.T) {
+	outputDir := t.TempDir()
+	generatedFile := filepath.Join(outputDir, "i18n.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte("// Code generated by i18ngen. DO NOT EDIT.\npackage i18n\n"), 0644))
+
+	run := func(args ...string) string {
+		var out bytes.Buffer
+		cmd := NewCleanCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs(append([]string{"--config", filepath.Join(outputDir, "missing.yaml"), "--output", outputDir}, args...))
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	assert.Equal(t, "would remove "+generatedFile+"\n", run("--dry-run"))
+	assert.FileExists(t, generatedFile)
+
+	assert.Equal(t, "removed "+generatedFile+"\n", run())
+	assert.NoFileExists(t, generatedFile)
+}
+
+func TestCleanCommand_LocaleOutputDir(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "i18ngen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("locales: [ja, en]\noutput_dir: i18n/{locale}\n"), 0644))
+	var generatedFiles []string
+	for _, locale := range []string{"ja", "en"} {
+		dir := filepath.Join(tempDir, "i18n", locale)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		generatedFile := filepath.Join(dir, "i18n.gen.go")
+		require.NoError(t, os.WriteFile(generatedFile, []byte("// Code generated by i18ngen. DO NOT EDIT.\npackage i18n\n"), 0644))
+		generatedFiles = append(generatedFiles, generatedFile)
+	}
+
+	var out bytes.Buffer
+	cmd := NewCleanCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--config", configPath})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "removed "+generatedFiles[0]+"\nremoved "+generatedFiles[1]+"\n", out.String())
+	for _, generatedFile := range generatedFiles {
+		assert.NoFileExists(t, generatedFile)
+	}
+}
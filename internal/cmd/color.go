@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ANSI escape sequences used for diagnostics
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
+)
+
+// forceColor and noColor override the detection of colored diagnostics output
+var (
+	forceColor bool
+	noColor    bool
+)
+
+// diagnosticPathPattern matches the input and config file paths in diagnostics, with an optional line
+var diagnosticPathPattern = regexp.MustCompile(`[^\s"'()]+\.(?:ya?ml|json|toml)(?::\d+)?`)
+
+// useColor reports whether diagnostics written to w are colored: --no-color and --color take
+// precedence, otherwise only a terminal gets colors, and never while NO_COLOR is set
+func useColor(w io.Writer) bool {
+	switch {
+	case noColor:
+		return false
+	case forceColor:
+		return true
+	case os.Getenv("NO_COLOR") != "":
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorizeError colors the first line of an error message red and dims the file paths in the
+// rest, such as the locations listed in suggestions
+func colorizeError(text string) string {
+	first, rest, found := strings.Cut(text, "\n")
+	colored := ansiRed + first + ansiReset
+	if found {
+		colored += "\n" + dimPaths(rest)
+	}
+	return colored
+}
+
+// dimPaths dims every file path in text
+func dimPaths(text string) string {
+	return diagnosticPathPattern.ReplaceAllString(text, ansiDim+"$0"+ansiReset)
+}
+
+// colorWarnings colors the "warning:" prefix of each line written to w yellow and dims file paths.
+// The generator writes every warning as whole lines in a single call.
+type colorWarnings struct {
+	w io.Writer
+}
+
+func (c colorWarnings) Write(p []byte) (int, error) {
+	lines := strings.SplitAfter(string(p), "\n")
+	for i, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "warning:"); ok {
+			line = ansiYellow + "warning:" + ansiReset + dimPaths(rest)
+		} else {
+			line = dimPaths(line)
+		}
+		lines[i] = line
+	}
+	if _, err := io.WriteString(c.w, strings.Join(lines, "")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// diagnosticsOutput returns where commands write warnings: the error output, colored when useColor allows
+func diagnosticsOutput(w io.Writer) io.Writer {
+	if useColor(w) {
+		return colorWarnings{w: w}
+	}
+	return w
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseColor(t *testing.T) {
+	t.Cleanup(func() { forceColor, noColor = false, false })
+
+	t.Run("not a terminal", func(t *testing.T) {
+		forceColor, noColor = false, false
+		t.Setenv("NO_COLOR", "")
+		assert.False(t, useColor(&bytes.Buffer{}))
+
+		file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+		assert.False(t, useColor(file))
+	})
+
+	t.Run("--color forces colors, even with NO_COLOR", func(t *testing.T) {
+		forceColor, noColor = true, false
+		t.Setenv("NO_COLOR", "1")
+		assert.True(t, useColor(&bytes.Buffer{}))
+	})
+
+	t.Run("--no-color wins over --color", func(t *testing.T) {
+		forceColor, noColor = true, true
+		assert.False(t, useColor(&bytes.Buffer{}))
+	})
+}
+
+func TestColorizeError(t *testing.T) {
+	assert.Equal(t, "\x1b[31mfailed to parse\x1b[0m\n  in \x1b[2mmessages/app.yaml:3\x1b[0m",
+		colorizeError("failed to parse\n  in messages/app.yaml:3"))
+	assert.Equal(t, "\x1b[31mboom\x1b[0m", colorizeError("boom"))
+}
+
+func TestColorWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	line := "warning: empty template for message \"Hello\" in messages/app.yaml:2\n"
+	n, err := fmt.Fprint(colorWarnings{w: &buf}, line)
+	require.NoError(t, err)
+	assert.Equal(t, len(line), n)
+	assert.Equal(t, "\x1b[33mwarning:\x1b[0m empty template for message \"Hello\" in \x1b[2mmessages/app.yaml:2\x1b[0m\n", buf.String())
+}
+
+func TestExecuteColoredError(t *testing.T) {
+	run := func(args ...string) string {
+		var stderr bytes.Buffer
+		root := newRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&stderr)
+		execute(root, args)
+		return stderr.String()
+	}
+	t.Cleanup(func() { forceColor, noColor = false, false })
+	t.Setenv("NO_COLOR", "")
+
+	args := []string{"report", "--config", filepath.Join(t.TempDir(), "none.yaml")}
+	assert.Equal(t, "no report selected: pass --stale\n", run(args...))
+	assert.Equal(t, "\x1b[31mno report selected: pass --stale\x1b[0m\n", run(append(args, "--color")...))
+	assert.Equal(t, "no report selected: pass --stale\n", run(append(args, "--color", "--no-color")...))
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// binaryName is the name of the installed i18ngen binary (go install names it after the module)
+const binaryName = "go-i18ngen"
+
+// BuildGenerateDirective returns the //go:generate line that reproduces a generate run
+// from within the output package directory. Paths are made relative to outputDir because
+// go generate runs commands in the directory of the file containing the directive.
+func BuildGenerateDirective(outputDir, configPath string, flags *Flags) (string, error) {
+	args := []string{binaryName, "generate"}
+
+	if _, err := os.Stat(configPath); err == nil {
+		rel, err := relativeTo(outputDir, configPath)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, "--config", rel)
+	}
+
+	if len(flags.Locales) > 0 {
+		args = append(args, "--locales", strings.Join(flags.Locales, ","))
+	}
+	if flags.Compound {
+		args = append(args, "--compound")
+	}
+	for _, pathFlag := range []struct {
+		name  string
+		value string
+	}{
+		{"messages", flags.MessagesGlob},
+		{"placeholders", flags.PlaceholdersGlob},
+		{"output", flags.OutputDir},
+	} {
+		if pathFlag.value == "" {
+			continue
+		}
+		rel, err := relativeTo(outputDir, pathFlag.value)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, "--"+pathFlag.name, rel)
+	}
+	if flags.OutputPackage != "" {
+		args = append(args, "--package", flags.OutputPackage)
+	}
+	if flags.Sort != "" {
+		args = append(args, "--sort", flags.Sort)
+	}
+	if flags.PackagePath != "" {
+		args = append(args, "--package-path", flags.PackagePath)
+	}
+	if flags.Trace {
+		args = append(args, "--trace")
+	}
+	if flags.OutputTest {
+		args = append(args, "--output-test")
+	}
+	if flags.Strict {
+		args = append(args, "--strict")
+	}
+	if flags.FailOnWarning {
+		args = append(args, "--fail-on-warning")
+	}
+	if flags.IfStale {
+		args = append(args, "--if-stale")
+	}
+	if flags.SinceGit {
+		args = append(args, "--since-git")
+	}
+	if flags.DataLayout != "" {
+		args = append(args, "--data-layout", flags.DataLayout)
+	}
+	if flags.MaxParallel != 0 {
+		args = append(args, "--max-parallel", strconv.Itoa(flags.MaxParallel))
+	}
+	if len(flags.Tags) > 0 {
+		args = append(args, "--tags", strings.Join(flags.Tags, ","))
+	}
+	if flags.InputFormat != "" {
+		args = append(args, "--input-format", flags.InputFormat)
+	}
+
+	for i, arg := range args {
+		args[i] = quoteDirectiveArg(arg)
+	}
+	return "//go:generate " + strings.Join(args, " "), nil
+}
+
+// relativeTo expresses path relative to base using forward slashes
+func relativeTo(base, path string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %q: %w", base, err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %q: %w", path, err)
+	}
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to make %q relative to %q: %w", path, base, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// quoteDirectiveArg quotes arguments that go generate would otherwise split or expand
+func quoteDirectiveArg(arg string) string {
+	if strings.ContainsAny(arg, " \t\"$") {
+		return fmt.Sprintf("%q", arg)
+	}
+	return arg
+}
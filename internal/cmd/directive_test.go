@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGenerateDirective(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "i18n", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte("locales: [ja]\n"), 0644))
+	outputDir := filepath.Join(tempDir, "internal", "i18n")
+
+	t.Run("config path relative to output directory", func(t *testing.T) {
+		directive, err := BuildGenerateDirective(outputDir, configPath, &Flags{})
+		require.NoError(t, err)
+		assert.Equal(t, "//go:generate go-i18ngen generate --config ../../i18n/config.yaml", directive)
+	})
+
+	t.Run("flags are carried over with relative paths", func(t *testing.T) {
+		directive, err := BuildGenerateDirective(outputDir, filepath.Join(tempDir, "missing.yaml"), &Flags{
+			Locales:       []string{"ja", "en"},
+			MessagesGlob:  filepath.Join(tempDir, "messages", "*.yaml"),
+			OutputDir:     outputDir,
+			OutputPackage: "i18n",
+		})
+		require.NoError(t, err)
+		assert.Equal(t,
+			"//go:generate go-i18ngen generate --locales ja,en --messages ../../messages/*.yaml --output . --package i18n",
+			directive)
+	})
+
+	t.Run("data layout, max parallel, tags and input format are carried over", func(t *testing.T) {
+		directive, err := BuildGenerateDirective(tempDir, filepath.Join(tempDir, "missing.yaml"), &Flags{
+			DataLayout:  "embed-file",
+			MaxParallel: 2,
+			Tags:        []string{"email", "transactional"},
+			InputFormat: "json",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "//go:generate go-i18ngen generate --data-layout embed-file --max-parallel 2 --tags email,transactional --input-format json", directive)
+	})
+
+	t.Run("arguments with spaces are quoted", func(t *testing.T) {
+		directive, err := BuildGenerateDirective(tempDir, filepath.Join(tempDir, "my config.yaml"), &Flags{
+			MessagesGlob: filepath.Join(tempDir, "my messages", "*.yaml"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `//go:generate go-i18ngen generate --messages "my messages/*.yaml"`, directive)
+	})
+}
+
+func TestGenerateCommandEmitDirective(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "messages"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "messages", "messages.yaml"), []byte(`Hello:
+  en: "Hello"
+`), 0644))
+	configPath := filepath.Join(tempDir, "i18ngen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`locales: [en]
+messages: "messages/*.yaml"
+placeholders: "placeholders/*.yaml"
+output_dir: "internal/i18n"
+output_package: "i18n"
+`), 0644))
+
+	var out bytes.Buffer
+	cmd := NewGenerateCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--config", configPath, "--emit-directive"})
+	require.NoError(t, cmd.Execute())
+
+	assert.FileExists(t, filepath.Join(tempDir, "internal", "i18n", "i18n.gen.go"))
+	assert.Equal(t, "//go:generate go-i18ngen generate --config ../../i18ngen.yaml", strings.TrimSpace(out.String()))
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/exporter"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates and returns the export command
+func NewExportCommand() *cobra.Command {
+	var (
+		exportConfigPath string
+		exportFlags      Flags
+		format           string
+		dir              string
+	)
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export messages to external translation file formats",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				return fmt.Errorf("export directory cannot be empty")
+			}
+			if !slices.Contains(exporter.Formats, format) {
+				return fmt.Errorf("unsupported export format %q: must be one of %s", format, strings.Join(exporter.Formats, ", "))
+			}
+
+			cfg, err := loadConfig(exportConfigPath)
+			if err != nil {
+				return err
+			}
+			merged := MergeConfig(cfg, &exportFlags)
+
+			corpus, err := generator.Load(merged)
+			if err != nil {
+				return err
+			}
+
+			var written []string
+			switch format {
+			case exporter.FormatCSV:
+				path, err := exporter.ExportCSV(dir, corpus.Messages, merged.Locales)
+				if err != nil {
+					return err
+				}
+				written = []string{path}
+			case exporter.FormatXLIFF:
+				written, err = exporter.ExportXLIFF(dir, corpus.Messages, merged.Locales, merged.GetPrimaryLocale())
+				if err != nil {
+					return err
+				}
+			case exporter.FormatGettextPOT:
+				path, err := exporter.ExportGettextPOT(dir, corpus.Messages, merged.GetPrimaryLocale())
+				if err != nil {
+					return err
+				}
+				written = []string{path}
+			default:
+				written, err = exporter.ExportGoI18n(dir, corpus.MessageTemplates, corpus.Definitions.Messages, merged.Locales)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, path := range written {
+				fmt.Fprintln(successOutput(cmd), path)
+			}
+			return nil
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&exportConfigPath, "config", "c", "", configFlagUsage)
+	exportCmd.Flags().StringSliceVar(&exportFlags.Locales, "locales", nil, "list of locales (e.g. ja,en)")
+	exportCmd.Flags().BoolVar(&exportFlags.Compound, "compound", false, "use compound format")
+	exportCmd.Flags().StringVar(&exportFlags.MessagesGlob, "messages", "", "messages glob pattern")
+	exportCmd.Flags().StringVar(&exportFlags.PlaceholdersGlob, "placeholders", "", "placeholders glob pattern")
+	exportCmd.Flags().StringVar(&format, "format", exporter.FormatGoI18n, "export format ("+strings.Join(exporter.Formats, ", ")+")")
+	exportCmd.Flags().StringVar(&dir, "dir", "", "directory to write exported files to")
+
+	return exportCmd
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`Hello:
+  ja: "こんにちは {{.name}}"
+  en: "Hello {{.name}}"
+`), 0644))
+
+	outDir := filepath.Join(tempDir, "out")
+
+	t.Run("exports go-i18n files", func(t *testing.T) {
+		cmd := NewExportCommand()
+		cmd.SetArgs([]string{
+			"--config", filepath.Join(tempDir, "missing.yaml"),
+			"--locales", "ja,en",
+			"--messages", filepath.Join(messagesDir, "*.yaml"),
+			"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+			"--format", "go-i18n",
+			"--dir", outDir,
+		})
+		require.NoError(t, cmd.Execute())
+
+		content, err := os.ReadFile(filepath.Join(outDir, "active.en.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "Hello: \"Hello {{.name}}\"\n", string(content))
+		assert.FileExists(t, filepath.Join(outDir, "active.ja.yaml"))
+	})
+
+	t.Run("exports XLIFF files per target locale", func(t *testing.T) {
+		xliffDir := filepath.Join(tempDir, "xliff")
+		cmd := NewExportCommand()
+		cmd.SetArgs([]string{
+			"--config", filepath.Join(tempDir, "missing.yaml"),
+			"--locales", "ja,en",
+			"--messages", filepath.Join(messagesDir, "*.yaml"),
+			"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+			"--format", "xliff",
+			"--dir", xliffDir,
+		})
+		require.NoError(t, cmd.Execute())
+
+		content, err := os.ReadFile(filepath.Join(xliffDir, "messages.en.xlf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `srcLang="ja" trgLang="en"`)
+		assert.Contains(t, string(content), "<target>Hello {{.name}}</target>")
+		assert.NoFileExists(t, filepath.Join(xliffDir, "messages.ja.xlf"))
+	})
+
+	t.Run("exports a gettext template of the primary locale", func(t *testing.T) {
+		potDir := filepath.Join(tempDir, "pot")
+		cmd := NewExportCommand()
+		cmd.SetArgs([]string{
+			"--config", filepath.Join(tempDir, "missing.yaml"),
+			"--locales", "ja,en",
+			"--messages", filepath.Join(messagesDir, "*.yaml"),
+			"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+			"--format", "gettext-pot",
+			"--dir", potDir,
+		})
+		require.NoError(t, cmd.Execute())
+
+		content, err := os.ReadFile(filepath.Join(potDir, "messages.pot"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "msgctxt \"Hello\"\nmsgid \"こんにちは {{.name}}\"\nmsgstr \"\"\n")
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		cmd := NewExportCommand()
+		cmd.SetArgs([]string{
+			"--config", filepath.Join(tempDir, "missing.yaml"),
+			"--locales", "ja,en",
+			"--messages", filepath.Join(messagesDir, "*.yaml"),
+			"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+			"--format", "xml",
+			"--dir", outDir,
+		})
+		cmd.SilenceUsage = true
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported export format")
+	})
+}
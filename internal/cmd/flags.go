@@ -8,4 +8,16 @@ type Flags struct {
 	PlaceholdersGlob string
 	OutputDir        string
 	OutputPackage    string
+	Sort             string
+	Trace            bool
+	OutputTest       bool
+	PackagePath      string
+	Strict           bool
+	FailOnWarning    bool
+	IfStale          bool
+	SinceGit         bool
+	MaxParallel      int
+	DataLayout       string
+	Tags             []string
+	InputFormat      string
 }
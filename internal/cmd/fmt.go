@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/formatter"
+
+	"github.com/spf13/cobra"
+)
+
+// NewFmtCommand creates and returns the fmt command
+func NewFmtCommand() *cobra.Command {
+	var (
+		fmtConfigPath string
+		fmtFlags      Flags
+	)
+
+	fmtCmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Rewrite message YAML files in canonical format",
+		Long: "Rewrite message YAML files in place with sorted message IDs, locales in config order,\n" +
+			"plural forms in CLDR order and consistent quoting. Comments are preserved.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(fmtConfigPath)
+			if err != nil {
+				return err
+			}
+			merged := MergeConfig(cfg, &fmtFlags)
+			if merged.MessagesGlob == "" {
+				return fmt.Errorf("messages glob pattern cannot be empty")
+			}
+
+			files, err := filepath.Glob(merged.MessagesGlob)
+			if err != nil {
+				return fmt.Errorf("invalid messages glob pattern %q: %w", merged.MessagesGlob, err)
+			}
+
+			for _, file := range files {
+				ext := strings.ToLower(filepath.Ext(file))
+				if ext != ".yaml" && ext != ".yml" {
+					continue
+				}
+				changed, err := formatter.FormatFile(file, merged.Locales)
+				if err != nil {
+					return err
+				}
+				if changed {
+					fmt.Fprintln(successOutput(cmd), file)
+				}
+			}
+			return nil
+		},
+	}
+
+	fmtCmd.Flags().StringVarP(&fmtConfigPath, "config", "c", "", configFlagUsage)
+	fmtCmd.Flags().StringSliceVar(&fmtFlags.Locales, "locales", nil, "list of locales (e.g. ja,en)")
+	fmtCmd.Flags().StringVar(&fmtFlags.MessagesGlob, "messages", "", "messages glob pattern")
+
+	return fmtCmd
+}
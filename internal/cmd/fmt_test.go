@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFmtCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	messagePath := filepath.Join(tempDir, "messages.yaml")
+	require.NoError(t, os.WriteFile(messagePath, []byte("B:\n  en: b\n  ja: ビー\nA:\n  en: a\n"), 0644))
+
+	cmd := NewFmtCommand()
+	cmd.SetArgs([]string{
+		"--config", filepath.Join(tempDir, "missing.yaml"),
+		"--messages", filepath.Join(tempDir, "*.yaml"),
+		"--locales", "ja,en",
+	})
+	require.NoError(t, cmd.Execute())
+
+	content, err := os.ReadFile(messagePath)
+	require.NoError(t, err)
+	assert.Equal(t, "A:\n  en: \"a\"\nB:\n  ja: \"ビー\"\n  en: \"b\"\n", string(content))
+}
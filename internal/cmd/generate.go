@@ -1,15 +1,25 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/hacomono-lib/go-i18ngen/internal/config"
 	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
-	configPath string
-	flags      Flags
+	configPath    string
+	flags         Flags
+	emitDirective bool
+	readStdin     bool
+	outputFile    string
+	printConfig   bool
 )
 
 // NewGenerateCommand creates and returns the generate command
@@ -18,26 +28,104 @@ func NewGenerateCommand() *cobra.Command {
 		Use:   "generate",
 		Short: "Generate i18n message and placeholder code",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.LoadConfig(configPath)
+			if outputFile != "" && !readStdin {
+				return fmt.Errorf("--output-file requires --stdin")
+			}
+			resolvedPath := configFile(configPath)
+			cfg, err := loadConfig(resolvedPath)
 			if err != nil {
 				return err
 			}
 			merged := MergeConfig(cfg, &flags)
-			return generator.Run(merged)
+			merged.ConfigPath = resolvedPath
+			if printConfig {
+				return writeEffectiveConfig(cmd.OutOrStdout(), merged)
+			}
+			warnings := &warningCounter{w: diagnosticsOutput(cmd.ErrOrStderr())}
+			merged.Warnings = warnings
+			if readStdin {
+				if err := generateFromStdin(cmd, merged); err != nil {
+					return err
+				}
+				return warnings.check(flags.FailOnWarning)
+			}
+			if err := generator.Run(merged); err != nil {
+				return err
+			}
+			if err := warnings.check(flags.FailOnWarning); err != nil {
+				return err
+			}
+
+			if emitDirective {
+				directive, err := BuildGenerateDirective(merged.OutputDir, resolvedPath, &flags)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), directive)
+			}
+			return nil
 		},
 	}
 
-	genCmd.Flags().StringVarP(&configPath, "config", "c", "i18ngen.yaml", "path to config file")
+	genCmd.Flags().StringVarP(&configPath, "config", "c", "", configFlagUsage)
 	genCmd.Flags().StringSliceVar(&flags.Locales, "locales", nil, "list of locales (e.g. ja,en)")
 	genCmd.Flags().BoolVar(&flags.Compound, "compound", false, "use compound format")
 	genCmd.Flags().StringVar(&flags.MessagesGlob, "messages", "", "messages glob pattern")
 	genCmd.Flags().StringVar(&flags.PlaceholdersGlob, "placeholders", "", "placeholders glob pattern")
 	genCmd.Flags().StringVar(&flags.OutputDir, "output", "", "output directory")
 	genCmd.Flags().StringVar(&flags.OutputPackage, "package", "", "output package name")
+	genCmd.Flags().StringVar(&flags.Sort, "sort", "", "output ordering: alpha or source")
+	genCmd.Flags().StringVar(&flags.PackagePath, "package-path", "", "import path of the output package; writes example/"+templatex.UsageExampleFileName+" demonstrating its API")
+	genCmd.Flags().BoolVar(&flags.Strict, "strict", false, "fail on problems that are otherwise reported as warnings, such as empty templates")
+	genCmd.Flags().BoolVar(&flags.FailOnWarning, "fail-on-warning", false, "exit with an error after generation if any warning was emitted")
+	genCmd.Flags().BoolVar(&flags.IfStale, "if-stale", false, "skip generation when no input file or the config file is newer than the generated output and the configuration is unchanged")
+	genCmd.Flags().BoolVar(&flags.SinceGit, "since-git", false, "skip generation when git shows no change to any input file or the config file in the working tree and the output was generated from them")
+	genCmd.Flags().StringVar(&flags.DataLayout, "data-layout", "", "where message data lives: inline, embed-file or external")
+	genCmd.Flags().IntVar(&flags.MaxParallel, "max-parallel", 0, "number of message files parsed at a time (default: number of CPUs)")
+	genCmd.Flags().StringVar(&flags.InputFormat, "input-format", "", "decode message and placeholder files as yaml or json regardless of their extension")
+	genCmd.Flags().StringSliceVar(&flags.Tags, "tags", nil, "generate only messages carrying one of these tags (e.g. email,transactional)")
+	genCmd.Flags().BoolVar(&flags.OutputTest, "output-test", false, "write "+templatex.SmokeTestFileName+" checking that every message renders in the primary locale")
+	genCmd.Flags().BoolVar(&flags.Trace, "trace", false, "write "+generator.TraceFileName+" mapping generated symbols to source files")
+	genCmd.Flags().BoolVar(&readStdin, "stdin", false, "read a single message document from stdin instead of message files and write the code to stdout")
+	genCmd.Flags().StringVar(&outputFile, "output-file", "", "with --stdin, write the generated code to this file instead of stdout")
+	genCmd.Flags().BoolVar(&emitDirective, "emit-directive", false, "print the //go:generate directive for the output package")
+	genCmd.Flags().BoolVar(&printConfig, "config-print", false, "print the effective configuration, after loading the config file and applying flags, as YAML and exit")
 
 	return genCmd
 }
 
+// writeEffectiveConfig writes cfg as YAML, preceded by a comment naming the config file it was loaded from
+func writeEffectiveConfig(w io.Writer, cfg *config.Config) error {
+	source := "# Loaded from " + cfg.ConfigPath
+	if _, err := os.Stat(cfg.ConfigPath); err != nil {
+		source = "# No config file found at " + cfg.ConfigPath + "; only flags apply"
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n%s", source, data)
+	return err
+}
+
+// generateFromStdin generates code from a message document piped to stdin and writes it
+// to --output-file, or to stdout when unset
+func generateFromStdin(cmd *cobra.Command, cfg *config.Config) error {
+	cfg.MessagesReader = cmd.InOrStdin()
+	if outputFile == "" {
+		return generator.RunTo(cfg, cmd.OutOrStdout())
+	}
+
+	code, err := generator.Generate(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputFile, code, 0600); err != nil {
+		return fmt.Errorf("failed to write generated code to %q: %w", outputFile, err)
+	}
+	return nil
+}
+
 // MergeConfig merges CLI flags with config file, prioritizing flags
 func MergeConfig(cfg *config.Config, flags *Flags) *config.Config {
 	if len(flags.Locales) > 0 {
@@ -58,5 +146,38 @@ func MergeConfig(cfg *config.Config, flags *Flags) *config.Config {
 	if flags.OutputPackage != "" {
 		cfg.OutputPackage = flags.OutputPackage
 	}
+	if flags.Sort != "" {
+		cfg.Sort = flags.Sort
+	}
+	if flags.Trace {
+		cfg.Trace = flags.Trace
+	}
+	if flags.OutputTest {
+		cfg.OutputTest = flags.OutputTest
+	}
+	if flags.PackagePath != "" {
+		cfg.PackagePath = flags.PackagePath
+	}
+	if flags.Strict {
+		cfg.Strict = flags.Strict
+	}
+	if flags.IfStale {
+		cfg.IfStale = flags.IfStale
+	}
+	if flags.SinceGit {
+		cfg.SinceGit = flags.SinceGit
+	}
+	if flags.MaxParallel != 0 {
+		cfg.MaxParallel = flags.MaxParallel
+	}
+	if flags.DataLayout != "" {
+		cfg.DataLayout = flags.DataLayout
+	}
+	if len(flags.Tags) > 0 {
+		cfg.Tags = flags.Tags
+	}
+	if flags.InputFormat != "" {
+		cfg.InputFormat = flags.InputFormat
+	}
 	return cfg
 }
@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestMergeConfig(t *testing.T) {
@@ -330,3 +334,181 @@ func TestGenerateCommandError(t *testing.T) {
 	err = cmd.Execute()
 	assert.Error(t, err, "Should fail with nonexistent config file")
 }
+
+func TestGenerateCommandDiscoversConfigInParentDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+	configContent := `
+locales: [en, ja]
+messages: "./messages/*.yaml"
+placeholders: "./placeholders/*.yaml"
+output_dir: "./internal/i18n"
+output_package: "i18n"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "i18ngen.yaml"), []byte(configContent), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "messages"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "messages", "messages.yaml"), []byte("Hello:\n  en: \"Hello\"\n  ja: \"こんにちは\"\n"), 0644))
+	subDir := filepath.Join(tempDir, "cmd", "server")
+	require.NoError(t, os.MkdirAll(subDir, 0755))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(subDir))
+
+	cmd := NewGenerateCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	// Paths in the config resolve relative to the config file, not the working directory
+	_, err = os.Stat(filepath.Join(tempDir, "internal", "i18n", "i18n.gen.go"))
+	assert.NoError(t, err, "generated file should be written next to the discovered config")
+}
+
+func TestGenerateCommandStdin(t *testing.T) {
+	messages := `Hello:
+  en: "Hello {{.name}}"
+  ja: "こんにちは {{.name}}"
+`
+
+	t.Run("writes code to stdout", func(t *testing.T) {
+		var out bytes.Buffer
+		cmd := NewGenerateCommand()
+		cmd.SetIn(strings.NewReader(messages))
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--config", filepath.Join(t.TempDir(), "none.yaml"), "--stdin", "--locales", "en,ja", "--package", "i18n"})
+		require.NoError(t, cmd.Execute())
+
+		assert.Contains(t, out.String(), "package i18n")
+		assert.Contains(t, out.String(), "func NewHello(name NameValue) Hello")
+	})
+
+	t.Run("writes code to --output-file", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "i18n.gen.go")
+		var out bytes.Buffer
+		cmd := NewGenerateCommand()
+		cmd.SetIn(strings.NewReader(messages))
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--config", filepath.Join(t.TempDir(), "none.yaml"), "--stdin", "--locales", "en,ja", "--package", "i18n", "--output-file", outputPath})
+		require.NoError(t, cmd.Execute())
+
+		assert.Empty(t, out.String())
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "package i18n")
+	})
+
+	t.Run("reports invalid input", func(t *testing.T) {
+		cmd := NewGenerateCommand()
+		cmd.SetIn(strings.NewReader("Hello: [unclosed\n"))
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--config", filepath.Join(t.TempDir(), "none.yaml"), "--stdin", "--locales", "en", "--package", "i18n"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "<stdin>")
+	})
+
+	t.Run("--output-file requires --stdin", func(t *testing.T) {
+		cmd := NewGenerateCommand()
+		cmd.SetArgs([]string{"--output-file", filepath.Join(t.TempDir(), "i18n.gen.go")})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--output-file requires --stdin")
+	})
+}
+
+func TestGenerateCommandFailOnWarning(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	messageContent := `Hello:
+  en: "Hello"
+  ja: ""
+Bye:
+  en: " "
+  ja: "さようなら"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	run := func(t *testing.T, outputDir string, extraArgs ...string) (string, error) {
+		var stderr bytes.Buffer
+		cmd := NewGenerateCommand()
+		cmd.SetErr(&stderr)
+		cmd.SetArgs(append([]string{
+			"--config", filepath.Join(tempDir, "none.yaml"),
+			"--locales", "en,ja",
+			"--messages", filepath.Join(messagesDir, "*.yaml"),
+			"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+			"--output", outputDir,
+			"--package", "i18n",
+		}, extraArgs...))
+		err := cmd.Execute()
+		return stderr.String(), err
+	}
+
+	t.Run("warnings alone do not fail", func(t *testing.T) {
+		stderr, err := run(t, t.TempDir())
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(stderr, "warning: "))
+	})
+
+	t.Run("fails after reporting every warning", func(t *testing.T) {
+		outputDir := t.TempDir()
+		stderr, err := run(t, outputDir, "--fail-on-warning")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 warning(s) emitted")
+		assert.Contains(t, stderr, `message "Bye" (locale: en)`)
+		assert.Contains(t, stderr, `message "Hello" (locale: ja)`)
+		assert.FileExists(t, filepath.Join(outputDir, "i18n.gen.go"), "the full pass still runs")
+	})
+}
+
+func TestWarningCounter(t *testing.T) {
+	var stderr bytes.Buffer
+	warnings := &warningCounter{w: &stderr}
+	var counter generator.WarningCounter = warnings
+
+	_, err := warnings.Write([]byte("warning: templates found:\n  first\n  second\n"))
+	require.NoError(t, err)
+	counter.WarningEmitted()
+
+	assert.NoError(t, warnings.check(false))
+	err = warnings.check(true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 warning(s) emitted")
+	assert.Equal(t, "warning: templates found:\n  first\n  second\n", stderr.String())
+}
+
+func TestGenerateCommandConfigPrint(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "i18ngen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("locales: [en, ja]\nmessages: \"./messages/*.yaml\"\noutput_dir: \"./out\"\n"), 0644))
+
+	t.Run("prints the merged configuration without generating", func(t *testing.T) {
+		var out bytes.Buffer
+		cmd := NewGenerateCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--config", configPath, "--config-print", "--package", "messages", "--locales", "ja"})
+		require.NoError(t, cmd.Execute())
+
+		var printed config.Config
+		require.NoError(t, yaml.Unmarshal(out.Bytes(), &printed))
+		assert.True(t, strings.HasPrefix(out.String(), "# Loaded from "+configPath+"\n"))
+		assert.Equal(t, []string{"ja"}, printed.Locales)
+		assert.Equal(t, "messages", printed.OutputPackage)
+		assert.Equal(t, filepath.Join(tempDir, "messages", "*.yaml"), printed.MessagesGlob)
+
+		_, err := os.Stat(filepath.Join(tempDir, "out"))
+		assert.True(t, os.IsNotExist(err), "nothing should be generated")
+	})
+
+	t.Run("reports a missing config file", func(t *testing.T) {
+		var out bytes.Buffer
+		missing := filepath.Join(tempDir, "missing.yaml")
+		cmd := NewGenerateCommand()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--config", missing, "--config-print"})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), "# No config file found at "+missing+"; only flags apply")
+	})
+}
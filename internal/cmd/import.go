@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+	"github.com/hacomono-lib/go-i18ngen/internal/importer"
+
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates and returns the import command
+func NewImportCommand() *cobra.Command {
+	var (
+		importConfigPath string
+		importFlags      Flags
+		format           string
+		file             string
+	)
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Merge translations from external file formats back into message files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return fmt.Errorf("import file cannot be empty")
+			}
+			if !slices.Contains(importer.Formats, format) {
+				return fmt.Errorf("unsupported import format %q: must be one of %s", format, strings.Join(importer.Formats, ", "))
+			}
+
+			cfg, err := loadConfig(importConfigPath)
+			if err != nil {
+				return err
+			}
+			merged := MergeConfig(cfg, &importFlags)
+
+			corpus, err := generator.Load(merged)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(file) // #nosec G304 - Opening the import file is intentional
+			if err != nil {
+				return fmt.Errorf("failed to open import file %q: %w", file, err)
+			}
+			defer func() { _ = f.Close() }()
+
+			written, err := importer.ImportCSV(f, corpus.Messages, merged.Locales)
+			if err != nil {
+				return fmt.Errorf("failed to import %q: %w", file, err)
+			}
+
+			for _, path := range written {
+				fmt.Fprintln(successOutput(cmd), path)
+			}
+			return nil
+		},
+	}
+
+	importCmd.Flags().StringVarP(&importConfigPath, "config", "c", "", configFlagUsage)
+	importCmd.Flags().StringSliceVar(&importFlags.Locales, "locales", nil, "list of locales (e.g. ja,en)")
+	importCmd.Flags().BoolVar(&importFlags.Compound, "compound", false, "use compound format")
+	importCmd.Flags().StringVar(&importFlags.MessagesGlob, "messages", "", "messages glob pattern")
+	importCmd.Flags().StringVar(&importFlags.PlaceholdersGlob, "placeholders", "", "placeholders glob pattern")
+	importCmd.Flags().StringVar(&format, "format", importer.FormatCSV, "import format ("+strings.Join(importer.Formats, ", ")+")")
+	importCmd.Flags().StringVar(&file, "file", "", "file to import translations from")
+
+	return importCmd
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	messageFile := filepath.Join(messagesDir, "messages.yaml")
+	require.NoError(t, os.WriteFile(messageFile, []byte(`Hello:
+  ja: "こんにちは {{.name}}"
+  en: "Hello {{.name}}"
+`), 0644))
+
+	commonArgs := []string{
+		"--config", filepath.Join(tempDir, "missing.yaml"),
+		"--locales", "ja,en",
+		"--messages", filepath.Join(messagesDir, "*.yaml"),
+		"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+	}
+	outDir := filepath.Join(tempDir, "out")
+	csvFile := filepath.Join(outDir, "messages.csv")
+
+	t.Run("round-trips through CSV", func(t *testing.T) {
+		exportCmd := NewExportCommand()
+		exportCmd.SetArgs(append(commonArgs, "--format", "csv", "--dir", outDir))
+		require.NoError(t, exportCmd.Execute())
+
+		content, err := os.ReadFile(csvFile)
+		require.NoError(t, err)
+		assert.Equal(t, "id,form,description,ja,en\nHello,,,こんにちは {{.name}},Hello {{.name}}\n", string(content))
+
+		// A translator edits the English column
+		edited := strings.Replace(string(content), ",Hello {{.name}}", ",Hi {{.name}}", 1)
+		require.NoError(t, os.WriteFile(csvFile, []byte(edited), 0644))
+
+		var out strings.Builder
+		importCmd := NewImportCommand()
+		importCmd.SetOut(&out)
+		importCmd.SetArgs(append(commonArgs, "--format", "csv", "--file", csvFile))
+		require.NoError(t, importCmd.Execute())
+		assert.Equal(t, messageFile+"\n", out.String())
+
+		messages, err := os.ReadFile(messageFile)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello:\n  ja: \"こんにちは {{.name}}\"\n  en: \"Hi {{.name}}\"\n", string(messages))
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		cmd := NewImportCommand()
+		cmd.SetArgs(append(commonArgs, "--format", "xliff", "--file", csvFile))
+		cmd.SilenceUsage = true
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported import format")
+	})
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+	"github.com/hacomono-lib/go-i18ngen/internal/report"
+
+	"github.com/spf13/cobra"
+)
+
+// NewReportCommand creates and returns the report command
+func NewReportCommand() *cobra.Command {
+	var (
+		reportConfigPath string
+		reportFlags      Flags
+		stale            bool
+		source           string
+		target           string
+		baselinePath     string
+		updateBaseline   bool
+	)
+
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report translations that need attention",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !stale {
+				return fmt.Errorf("no report selected: pass --stale")
+			}
+			if target == "" {
+				return fmt.Errorf("target locale cannot be empty")
+			}
+			if updateBaseline && baselinePath == "" {
+				return fmt.Errorf("--update-baseline requires --baseline")
+			}
+
+			cfg, err := loadConfig(reportConfigPath)
+			if err != nil {
+				return err
+			}
+			merged := MergeConfig(cfg, &reportFlags)
+
+			corpus, err := generator.Load(merged)
+			if err != nil {
+				return err
+			}
+			if source == "" {
+				source = corpus.PrimaryLocale
+			}
+
+			if updateBaseline {
+				return report.NewBaseline(corpus.Messages, source, target).Write(baselinePath)
+			}
+
+			var baseline *report.Baseline
+			if baselinePath != "" {
+				baseline, err = report.LoadBaseline(baselinePath, source, target)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, entry := range report.Stale(corpus.Messages, source, target, baseline) {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", entry.ID, entry.Reason)
+			}
+			return nil
+		},
+	}
+
+	reportCmd.Flags().StringVarP(&reportConfigPath, "config", "c", "", configFlagUsage)
+	reportCmd.Flags().StringSliceVar(&reportFlags.Locales, "locales", nil, "list of locales (e.g. ja,en)")
+	reportCmd.Flags().BoolVar(&reportFlags.Compound, "compound", false, "use compound format")
+	reportCmd.Flags().StringVar(&reportFlags.MessagesGlob, "messages", "", "messages glob pattern")
+	reportCmd.Flags().StringVar(&reportFlags.PlaceholdersGlob, "placeholders", "", "placeholders glob pattern")
+	reportCmd.Flags().BoolVar(&stale, "stale", false, "report outdated or untranslated target entries")
+	reportCmd.Flags().StringVar(&source, "source", "", "source locale (default: first configured locale)")
+	reportCmd.Flags().StringVar(&target, "target", "", "target locale to check")
+	reportCmd.Flags().StringVar(&baselinePath, "baseline", "", "baseline file recording source text hashes")
+	reportCmd.Flags().BoolVar(&updateBaseline, "update-baseline", false, "record the current source texts in the baseline instead of reporting")
+
+	return reportCmd
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	messageFile := filepath.Join(messagesDir, "messages.yaml")
+	require.NoError(t, os.WriteFile(messageFile, []byte(`Greeting:
+  en: "Hello"
+  ja: "こんにちは"
+Farewell:
+  en: "Goodbye"
+  ja: "Goodbye"
+`), 0644))
+
+	baselinePath := filepath.Join(tempDir, "baseline.json")
+	run := func(extra ...string) (string, error) {
+		var out strings.Builder
+		cmd := NewReportCommand()
+		cmd.SetOut(&out)
+		cmd.SilenceUsage = true
+		cmd.SetArgs(append([]string{
+			"--config", filepath.Join(tempDir, "missing.yaml"),
+			"--locales", "en,ja",
+			"--messages", filepath.Join(messagesDir, "*.yaml"),
+			"--placeholders", filepath.Join(tempDir, "placeholders", "*.yaml"),
+			"--stale", "--target", "ja", "--baseline", baselinePath,
+		}, extra...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	t.Run("reports untranslated entries", func(t *testing.T) {
+		out, err := run()
+		require.NoError(t, err)
+		assert.Equal(t, "Farewell: untranslated\n", out)
+	})
+
+	t.Run("reports source changes since the baseline", func(t *testing.T) {
+		_, err := run("--update-baseline")
+		require.NoError(t, err)
+		assert.FileExists(t, baselinePath)
+
+		require.NoError(t, os.WriteFile(messageFile, []byte(`Greeting:
+  en: "Hello there"
+  ja: "こんにちは"
+Farewell:
+  en: "Goodbye"
+  ja: "さようなら"
+`), 0644))
+
+		out, err := run()
+		require.NoError(t, err)
+		assert.Equal(t, "Greeting: source changed\n", out)
+	})
+
+	t.Run("requires a report", func(t *testing.T) {
+		cmd := NewReportCommand()
+		cmd.SilenceUsage = true
+		cmd.SetArgs([]string{"--target", "ja"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pass --stale")
+	})
+}
@@ -1,24 +1,119 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+
 	"github.com/spf13/cobra"
 )
 
-var rootCmd = &cobra.Command{
-	Use:   "i18ngen",
-	Short: "i18ngen is a code generator for i18n message and placeholders",
+// Exit codes returned by the CLI, so scripts can branch on the cause of a failure
+const (
+	// ExitOK reports success
+	ExitOK = 0
+	// ExitError reports any failure not covered by a more specific code
+	ExitError = 1
+	// ExitValidation reports message or placeholder files failing validation
+	ExitValidation = 2
+	// ExitConfig reports an unreadable or invalid configuration
+	ExitConfig = 3
+)
+
+// quiet suppresses the output commands print on success
+var quiet bool
+
+// newRootCommand creates the root command with every subcommand registered
+func newRootCommand() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:           "i18ngen",
+		Short:         "i18ngen is a code generator for i18n message and placeholders",
+		Long:          "i18ngen is a code generator for i18n message and placeholders.\n\nWithout a command, it runs generate with the nearest config file.",
+		SilenceErrors: true,
+		// Without a subcommand, generate like `i18ngen generate` with default flags
+		RunE: func(cmd *cobra.Command, args []string) error {
+			generate, _, err := cmd.Find([]string{"generate"})
+			if err != nil {
+				return err
+			}
+			return generate.RunE(generate, args)
+		},
+	}
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress output printed on success")
+	rootCmd.PersistentFlags().BoolVar(&forceColor, "color", false, "color errors and warnings even when not writing to a terminal")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "never color errors and warnings (also set by the NO_COLOR environment variable)")
+
+	rootCmd.AddCommand(NewGenerateCommand())
+	rootCmd.AddCommand(NewFmtCommand())
+	rootCmd.AddCommand(NewExportCommand())
+	rootCmd.AddCommand(NewImportCommand())
+	rootCmd.AddCommand(NewReportCommand())
+	rootCmd.AddCommand(NewStatsCommand())
+	rootCmd.AddCommand(NewCleanCommand())
+	return rootCmd
 }
 
-// Execute runs the root command.
+// Execute runs the root command and exits with the code matching the outcome.
 func Execute() {
-	// Add generate command
-	rootCmd.AddCommand(NewGenerateCommand())
+	os.Exit(execute(newRootCommand(), os.Args[1:]))
+}
 
+// execute runs rootCmd with args, prints any error to stderr and returns the exit code
+func execute(rootCmd *cobra.Command, args []string) int {
+	rootCmd.SetArgs(args)
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		message := err.Error()
+		if useColor(rootCmd.ErrOrStderr()) {
+			message = colorizeError(message)
+		}
+		fmt.Fprintln(rootCmd.ErrOrStderr(), message)
+		return ExitCode(err)
+	}
+	return ExitOK
+}
+
+// ExitCode maps an error to the exit code documented for its class
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, generator.ErrInvalidConfig):
+		return ExitConfig
+	case errors.Is(err, generator.ErrInvalidInput):
+		return ExitValidation
+	default:
+		return ExitError
+	}
+}
+
+// configFlagUsage describes the --config flag shared by every command
+const configFlagUsage = "path to config file (default: nearest i18ngen.yaml, i18ngen.yml, i18ngen.json or i18ngen.toml in this or a parent directory)"
+
+// configFile returns path, or the config file discovered from the working directory upward when path is empty
+func configFile(path string) string {
+	if path == "" {
+		return config.FindConfigFile(".")
+	}
+	return path
+}
+
+// loadConfig loads the config file, classifying failures as configuration errors
+func loadConfig(path string) (*config.Config, error) {
+	cfg, err := config.LoadConfig(configFile(path))
+	if err != nil {
+		return nil, generator.ConfigError(err)
+	}
+	return cfg, nil
+}
+
+// successOutput returns where commands report what they did; --quiet discards it
+func successOutput(cmd *cobra.Command) io.Writer {
+	if quiet {
+		return io.Discard
 	}
+	return cmd.OutOrStdout()
 }
@@ -1,28 +1,137 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
 )
 
-func TestExecute(t *testing.T) {
-	// Test that Execute function doesn't panic
-	// Note: We can't easily test the actual execution without mocking cobra.Command
-	// but we can ensure the function exists and is callable
-	assert.NotPanics(t, func() {
-		// This would normally start the CLI, but for testing we just ensure it exists
-		// Execute()
-	})
+func TestRootCommand(t *testing.T) {
+	cmd := newRootCommand()
+	assert.Equal(t, "i18ngen", cmd.Use)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("quiet"))
+
+	for _, name := range []string{"generate", "fmt", "export", "import", "report", "clean"} {
+		sub, _, err := cmd.Find([]string{name})
+		require.NoError(t, err)
+		assert.Equal(t, name, sub.Name())
+	}
 }
 
-func TestRootCommand(t *testing.T) {
-	// Test basic root command properties
-	// Since Execute() sets up the root command, we test indirectly
-	cmd := NewGenerateCommand()
-	assert.NotNil(t, cmd, "Should be able to create generate command")
-
-	// Test that the command has expected properties
-	assert.Equal(t, "generate", cmd.Use)
-	assert.NotEmpty(t, cmd.Short)
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, ExitCode(nil))
+	assert.Equal(t, ExitError, ExitCode(errors.New("boom")))
+	assert.Equal(t, ExitConfig, ExitCode(generator.ConfigError(errors.New("bad config"))))
+	assert.Equal(t, ExitValidation, ExitCode(fmt.Errorf("wrapped: %w", generator.InputError(errors.New("bad input")))))
+}
+
+func TestExecuteExitCodes(t *testing.T) {
+	run := func(t *testing.T, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		root := newRootCommand()
+		root.SetOut(&stdout)
+		root.SetErr(&stderr)
+		code := execute(root, args)
+		return code, stdout.String(), stderr.String()
+	}
+
+	writeFile := func(t *testing.T, path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	generateArgs := func(dir string) []string {
+		return []string{
+			"generate",
+			"--config", filepath.Join(dir, "none.yaml"),
+			"--locales", "en,ja",
+			"--messages", filepath.Join(dir, "messages", "*.yaml"),
+			"--placeholders", filepath.Join(dir, "placeholders", "*.yaml"),
+			"--output", filepath.Join(dir, "out"),
+			"--package", "i18n",
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "messages", "messages.yaml"), "Hello:\n  en: \"Hello\"\n")
+
+		code, _, stderr := run(t, generateArgs(dir)...)
+		assert.Equal(t, ExitOK, code, stderr)
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "messages", "messages.yaml"), "Hello:\n  en: \"{{.name}} {{.name}}\"\n")
+
+		code, _, stderr := run(t, generateArgs(dir)...)
+		assert.Equal(t, ExitValidation, code)
+		assert.Contains(t, stderr, "duplicate placeholder")
+	})
+
+	t.Run("config error", func(t *testing.T) {
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "i18ngen.yaml")
+		writeFile(t, configPath, "locales: [unclosed\n")
+
+		code, _, stderr := run(t, "generate", "--config", configPath)
+		assert.Equal(t, ExitConfig, code)
+		assert.Contains(t, stderr, "failed to parse config file")
+	})
+
+	t.Run("invalid setting", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "messages", "messages.yaml"), "Hello:\n  en: \"Hello\"\n")
+
+		code, _, _ := run(t, append(generateArgs(dir), "--sort", "random")...)
+		assert.Equal(t, ExitConfig, code)
+	})
+
+	t.Run("generates without a command", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "i18ngen.yaml"), "locales: [en]\nmessages: ./messages/*.yaml\nplaceholders: ./placeholders/*.yaml\noutput_dir: ./out\n")
+		writeFile(t, filepath.Join(dir, "messages", "messages.yaml"), "Hello:\n  en: \"Hello\"\n")
+		originalDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(dir))
+		defer func() { _ = os.Chdir(originalDir) }()
+
+		code, _, stderr := run(t)
+		require.Equal(t, ExitOK, code, stderr)
+		assert.FileExists(t, filepath.Join(dir, "out", "i18n.gen.go"))
+
+		code, stdout, _ := run(t, "--help")
+		assert.Equal(t, ExitOK, code)
+		assert.Contains(t, stdout, "Available Commands")
+	})
+
+	t.Run("generic error", func(t *testing.T) {
+		code, _, stderr := run(t, "no-such-command")
+		assert.Equal(t, ExitError, code)
+		assert.Contains(t, stderr, "unknown command")
+	})
+
+	t.Run("quiet suppresses success output", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "i18n.gen.go"), "// Code generated by i18ngen. DO NOT EDIT.\npackage i18n\n")
+		args := []string{"clean", "--config", filepath.Join(dir, "none.yaml"), "--output", dir}
+
+		code, stdout, _ := run(t, append(args, "--dry-run", "--quiet")...)
+		require.Equal(t, ExitOK, code)
+		assert.Contains(t, stdout, "would remove", "the dry-run listing is the requested output")
+
+		code, stdout, _ = run(t, append(args, "--quiet")...)
+		require.Equal(t, ExitOK, code)
+		assert.Empty(t, stdout)
+		assert.NoFileExists(t, filepath.Join(dir, "i18n.gen.go"))
+	})
 }
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+	"github.com/hacomono-lib/go-i18ngen/internal/report"
+
+	"github.com/spf13/cobra"
+)
+
+// NewStatsCommand creates and returns the stats command
+func NewStatsCommand() *cobra.Command {
+	var (
+		statsConfigPath string
+		statsFlags      Flags
+	)
+
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize corpus size, feature usage and locale coverage",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(statsConfigPath)
+			if err != nil {
+				return err
+			}
+			merged := MergeConfig(cfg, &statsFlags)
+
+			corpus, err := generator.Load(merged)
+			if err != nil {
+				return err
+			}
+
+			stats := report.ComputeStats(corpus.Messages, corpus.Placeholders, corpus.Definitions, merged.Locales)
+			return stats.Write(cmd.OutOrStdout())
+		},
+	}
+
+	statsCmd.Flags().StringVarP(&statsConfigPath, "config", "c", "", configFlagUsage)
+	statsCmd.Flags().StringSliceVar(&statsFlags.Locales, "locales", nil, "list of locales (e.g. ja,en)")
+	statsCmd.Flags().BoolVar(&statsFlags.Compound, "compound", false, "use compound format")
+	statsCmd.Flags().StringVar(&statsFlags.MessagesGlob, "messages", "", "messages glob pattern")
+	statsCmd.Flags().StringVar(&statsFlags.PlaceholdersGlob, "placeholders", "", "placeholders glob pattern")
+	statsCmd.Flags().StringSliceVar(&statsFlags.Tags, "tags", nil, "count only messages carrying one of these tags")
+
+	return statsCmd
+}
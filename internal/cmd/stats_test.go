@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	placeholdersDir := filepath.Join(tempDir, "placeholders")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`Greeting:
+  en: "Hello {{.entity}}"
+  ja: "こんにちは {{.entity}}"
+Farewell:
+  en: "Goodbye"
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(placeholdersDir, "entity.yaml"), []byte(`user:
+  en: "User"
+  ja: "ユーザー"
+`), 0644))
+
+	var out strings.Builder
+	cmd := NewStatsCommand()
+	cmd.SetOut(&out)
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{
+		"--config", filepath.Join(tempDir, "missing.yaml"),
+		"--locales", "en,ja",
+		"--messages", filepath.Join(messagesDir, "*.yaml"),
+		"--placeholders", filepath.Join(placeholdersDir, "*.yaml"),
+	})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), "Messages                   2\n")
+	assert.Contains(t, out.String(), "  entity                   1\n")
+	assert.Contains(t, out.String(), "  ja  1/2  50.0%\n")
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+)
+
+// warningCounter forwards warnings to w and counts them, so --fail-on-warning can report every
+// warning before failing
+type warningCounter struct {
+	w     io.Writer
+	count int
+}
+
+func (c *warningCounter) Write(p []byte) (int, error) {
+	return c.w.Write(p)
+}
+
+// WarningEmitted implements generator.WarningCounter
+func (c *warningCounter) WarningEmitted() {
+	c.count++
+}
+
+// check returns an error when failOnWarning is set and any warning was emitted
+func (c *warningCounter) check(failOnWarning bool) error {
+	if !failOnWarning || c.count == 0 {
+		return nil
+	}
+	return generator.InputError(fmt.Errorf("%d warning(s) emitted and --fail-on-warning is set", c.count))
+}
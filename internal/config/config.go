@@ -2,17 +2,57 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	// DefaultPluralPlaceholder is the default plural placeholder name
 	DefaultPluralPlaceholder = "Count"
+	// DefaultSuffixSeparator separates a placeholder name from its suffix (e.g. {{.entity:from}})
+	DefaultSuffixSeparator = ":"
+	// DefaultValueSuffix is appended to the name of value placeholder types (e.g. ReasonValue)
+	DefaultValueSuffix = "Value"
+
+	// SortAlpha orders generated messages and placeholders alphabetically (default)
+	SortAlpha = "alpha"
+	// SortSource preserves the order in which messages and placeholders appear in source files
+	SortSource = "source"
+
+	// BackendGoI18n embeds all messages in the generated code (default)
+	BackendGoI18n = "go-i18n"
+	// BackendFilesystem additionally loads messages from a directory at runtime, using embedded data as fallback
+	BackendFilesystem = "filesystem"
+
+	// ValueStyleTyped wraps fields without a placeholder file in generated Value types (default)
+	ValueStyleTyped = "typed"
+	// ValueStylePlain passes fields without a placeholder file to constructors as plain strings
+	ValueStylePlain = "plain"
+
+	// DataLayoutInline inlines message and placeholder data in the generated code (default)
+	DataLayoutInline = "inline"
+	// DataLayoutEmbedFile writes the data to files next to the generated code and embeds them with go:embed
+	DataLayoutEmbedFile = "embed-file"
+	// DataLayoutExternal writes the message files next to the generated code to be loaded at runtime
+	DataLayoutExternal = "external"
+
+	// InputFormatYAML decodes message and placeholder files as YAML whatever their extension
+	InputFormatYAML = "yaml"
+	// InputFormatJSON decodes message and placeholder files as JSON whatever their extension
+	InputFormatJSON = "json"
+
+	// CountTypeInt stores the plural count of messages as int (default)
+	CountTypeInt = "int"
+	// CountTypeInt64 stores the plural count of messages as int64
+	CountTypeInt64 = "int64"
 )
 
 // Config holds configuration for i18ngen
@@ -24,9 +64,99 @@ type Config struct {
 	OutputDir         string   `yaml:"output_dir"`
 	OutputPackage     string   `yaml:"output_package"`
 	PluralPlaceholder string   `yaml:"plural_placeholder"`
+	Sort              string   `yaml:"sort"`
+	Backend           string   `yaml:"backend"`
+	TranslationsDir   string   `yaml:"translations_dir"`
+	Trace             bool     `yaml:"trace"`
+	OutputTest        bool     `yaml:"output_test"`
+	Localizer         bool     `yaml:"localizer"`
+	PackagePath       string   `yaml:"package_path"`
+	PrimaryLocale     string   `yaml:"primary_locale"`
+	BuildTags         []string `yaml:"build_tags"`
+	ValueStyle        string   `yaml:"value_style"`
+	PlaceholderConsts bool     `yaml:"emit_placeholder_consts"`
+	Strict            bool     `yaml:"strict"`
+	SuffixSeparator   string   `yaml:"suffix_separator"`
+	ParamsMinFields   int      `yaml:"params_constructor_min_fields"`
+	AutofillFrom      string   `yaml:"autofill_from"`
+	Coverage          bool     `yaml:"emit_coverage"`
+	LocalizeByID      bool     `yaml:"emit_localize_by_id"`
+	ImplementError    bool     `yaml:"implement_error"`
+	DataLayout        string   `yaml:"data_layout"`
+	NonPluralLocales  []string `yaml:"non_plural_locales"`
+	TemplateFunctions []string `yaml:"template_functions"`
+	MaxParallel       int      `yaml:"max_parallel"`
+	Tags              []string `yaml:"tags"`
+	InputFormat       string   `yaml:"input_format"`
+	CountType         string   `yaml:"count_type"`
+	LocaleInfo        bool     `yaml:"emit_locale_info"`
+	ValueSuffix       *string  `yaml:"value_suffix"`
+
+	RuntimePlaceholders map[string]RuntimePlaceholder `yaml:"runtime_placeholders"`
+	EnumPlaceholders    map[string][]string           `yaml:"enum_placeholders"`
+	LocaleNames         map[string]LocaleName         `yaml:"locale_info"`
+
+	// MessagesReader, when set, provides a single message document read instead of MessagesGlob
+	MessagesReader io.Reader `yaml:"-"`
+	// Warnings receives problems that fail generation only in strict mode; nil discards them
+	Warnings io.Writer `yaml:"-"`
+	// IfStale skips generation when no input, including ConfigPath, is newer than the outputs and
+	// the output records the digest of the current inputs
+	IfStale bool `yaml:"-"`
+	// SinceGit skips generation when git shows no change to any input in the working tree and
+	// the output records the digest of the current inputs
+	SinceGit bool `yaml:"-"`
+	// LocalesOnly drops message and placeholder texts in locales other than Locales; it is set for
+	// the package of each locale when OutputDir contains {locale}
+	LocalesOnly bool `yaml:"-"`
+	// ConfigPath is the file the configuration was loaded from, checked by IfStale and SinceGit
+	ConfigPath string `yaml:"-"`
+}
+
+// RuntimePlaceholder declares a placeholder resolved by a function set at runtime instead of static data
+type RuntimePlaceholder struct {
+	Runtime bool `yaml:"runtime"`
+}
+
+// LocaleName overrides the built-in display information of a locale in the generated LocaleInfo
+type LocaleName struct {
+	Name       string `yaml:"name"`
+	NativeName string `yaml:"native_name"`
+	RTL        bool   `yaml:"rtl"`
 }
 
-// LoadConfig loads configuration from a YAML file
+// DefaultConfigNames lists the configuration files tried, in order, when no path is given
+var DefaultConfigNames = []string{"i18ngen.yaml", "i18ngen.yml", "i18ngen.json", "i18ngen.toml"}
+
+// FindConfigFile returns the first of DefaultConfigNames that exists in dir or, failing that,
+// in the nearest parent directory that has one, the way git finds its repository.
+// It returns the YAML name in dir when no directory up to the filesystem root has a config file.
+func FindConfigFile(dir string) string {
+	current := dir
+	for {
+		for _, name := range DefaultConfigNames {
+			path := filepath.Join(current, name)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+
+		// Keep the walk relative to dir so returned paths read like "../i18ngen.yaml"
+		parent := filepath.Join(current, "..")
+		currentAbs, err := filepath.Abs(current)
+		if err != nil {
+			break
+		}
+		parentAbs, err := filepath.Abs(parent)
+		if err != nil || parentAbs == currentAbs {
+			break
+		}
+		current = parent
+	}
+	return filepath.Join(dir, DefaultConfigNames[0])
+}
+
+// LoadConfig loads configuration from a YAML, JSON or TOML file, chosen by the file extension
 func LoadConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path) // #nosec G304 - Reading configuration file is intentional
 	if err != nil {
@@ -45,7 +175,7 @@ func LoadConfig(path string) (*Config, error) {
 		PluralPlaceholder: DefaultPluralPlaceholder,
 	}
 
-	if err := yaml.Unmarshal(data, config); err != nil {
+	if err := unmarshalConfig(path, data, config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file %q: %w", path, err)
 	}
 
@@ -64,6 +194,30 @@ func LoadConfig(path string) (*Config, error) {
 	return config, nil
 }
 
+// unmarshalConfig decodes data into config according to the extension of path.
+// JSON and TOML documents are re-encoded as YAML so the yaml tags on Config apply to every format.
+func unmarshalConfig(path string, data []byte, config *Config) error {
+	var document map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &document); err != nil {
+			return err
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &document); err != nil {
+			return err
+		}
+	default:
+		return yaml.Unmarshal(data, config)
+	}
+
+	converted, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(converted, config)
+}
+
 // GetPluralPlaceholder returns the configured plural placeholder name
 func (c *Config) GetPluralPlaceholder() string {
 	if c.PluralPlaceholder == "" {
@@ -72,7 +226,73 @@ func (c *Config) GetPluralPlaceholder() string {
 	return c.PluralPlaceholder
 }
 
+// GetPrimaryLocale returns the locale used for fallback and item sorting:
+// the configured primary locale, or the first entry of Locales when unset
+func (c *Config) GetPrimaryLocale() string {
+	if c.PrimaryLocale != "" {
+		return c.PrimaryLocale
+	}
+	if len(c.Locales) > 0 {
+		return c.Locales[0]
+	}
+	return "en" // Default fallback
+}
+
+// GetCountType returns the Go type of the plural count of messages
+func (c *Config) GetCountType() string {
+	if c.CountType == "" {
+		return CountTypeInt
+	}
+	return c.CountType
+}
+
+// GetValueSuffix returns the suffix of value placeholder type names; an explicit empty
+// value_suffix generates the bare placeholder name
+func (c *Config) GetValueSuffix() string {
+	if c.ValueSuffix == nil {
+		return DefaultValueSuffix
+	}
+	return *c.ValueSuffix
+}
+
 // IsPluralPlaceholder checks if a placeholder name is the configured plural placeholder (case-insensitive)
 func (c *Config) IsPluralPlaceholder(name string) bool {
 	return strings.EqualFold(name, c.GetPluralPlaceholder())
 }
+
+// PlainValues reports whether fields without a placeholder file are generated as plain strings
+func (c *Config) PlainValues() bool {
+	return c.ValueStyle == ValueStylePlain
+}
+
+// RuntimePlaceholderNames returns the sorted names of the placeholders declared with runtime: true
+func (c *Config) RuntimePlaceholderNames() []string {
+	names := make([]string, 0, len(c.RuntimePlaceholders))
+	for name, placeholder := range c.RuntimePlaceholders {
+		if placeholder.Runtime {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnumPlaceholderNames returns the sorted names of the placeholders restricted to an allowed set of values
+func (c *Config) EnumPlaceholderNames() []string {
+	names := make([]string, 0, len(c.EnumPlaceholders))
+	for name := range c.EnumPlaceholders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DataFiles reports whether data is written to files next to the generated code
+func (c *Config) DataFiles() bool {
+	return c.DataLayout == DataLayoutEmbedFile || c.DataLayout == DataLayoutExternal
+}
+
+// SortBySource reports whether generated output should follow source file order
+func (c *Config) SortBySource() bool {
+	return c.Sort == SortSource
+}
@@ -127,6 +127,36 @@ func (s *ConfigTestSuite) TestGetPluralPlaceholder() {
 	}
 }
 
+func (s *ConfigTestSuite) TestGetPrimaryLocale() {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:     "first locale by default",
+			config:   &Config{Locales: []string{"ja", "en"}},
+			expected: "ja",
+		},
+		{
+			name:     "configured primary locale",
+			config:   &Config{Locales: []string{"en", "ja"}, PrimaryLocale: "ja"},
+			expected: "ja",
+		},
+		{
+			name:     "no locales",
+			config:   &Config{},
+			expected: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, tt.config.GetPrimaryLocale())
+		})
+	}
+}
+
 func (s *ConfigTestSuite) TestLoadConfigWithPluralPlaceholder() {
 	// Create a temporary config file
 	configPath := filepath.Join(s.tempDir, "config.yaml")
@@ -150,6 +180,36 @@ plural_placeholder: "CustomTotal"
 	s.False(config.IsPluralPlaceholder("Count")) // Not the custom one
 }
 
+func (s *ConfigTestSuite) TestLoadConfigWithRuntimePlaceholders() {
+	configPath := filepath.Join(s.tempDir, "runtime.yaml")
+	configContent := `
+runtime_placeholders:
+  tenantName: {runtime: true}
+  appName: {runtime: true}
+  disabled: {runtime: false}
+`
+	s.Require().NoError(os.WriteFile(configPath, []byte(configContent), 0644))
+
+	config, err := LoadConfig(configPath)
+	s.Require().NoError(err)
+	s.Equal([]string{"appName", "tenantName"}, config.RuntimePlaceholderNames())
+}
+
+func (s *ConfigTestSuite) TestLoadConfigWithEnumPlaceholders() {
+	configPath := filepath.Join(s.tempDir, "enum.yaml")
+	configContent := `
+enum_placeholders:
+  status: [pending, done]
+  priority: [low, high]
+`
+	s.Require().NoError(os.WriteFile(configPath, []byte(configContent), 0644))
+
+	config, err := LoadConfig(configPath)
+	s.Require().NoError(err)
+	s.Equal([]string{"priority", "status"}, config.EnumPlaceholderNames())
+	s.Equal([]string{"pending", "done"}, config.EnumPlaceholders["status"])
+}
+
 func (s *ConfigTestSuite) TestLoadConfigWithoutPluralPlaceholder() {
 	// Create a temporary config file without plural_placeholder
 	configPath := filepath.Join(s.tempDir, "config.yaml")
@@ -197,6 +257,109 @@ invalid: yaml: content:
 	s.Contains(err.Error(), "failed to parse config file")
 }
 
+func (s *ConfigTestSuite) TestLoadConfigJSON() {
+	subDir := filepath.Join(s.tempDir, "json")
+	s.Require().NoError(os.MkdirAll(subDir, 0755))
+	configPath := filepath.Join(subDir, "i18ngen.json")
+	configContent := `{
+  "locales": ["ja", "en"],
+  "compound": false,
+  "messages": "../messages/*.yaml",
+  "params_constructor_min_fields": 3,
+  "runtime_placeholders": {"tenantName": {"runtime": true}},
+  "enum_placeholders": {"status": ["pending", "done"]}
+}`
+	s.Require().NoError(os.WriteFile(configPath, []byte(configContent), 0644))
+
+	config, err := LoadConfig(configPath)
+	s.Require().NoError(err)
+	s.Equal([]string{"ja", "en"}, config.Locales)
+	s.False(config.Compound)
+	s.Equal(3, config.ParamsMinFields)
+	s.Equal(filepath.Join(s.tempDir, "messages", "*.yaml"), config.MessagesGlob)
+	s.Equal("i18n", config.OutputPackage, "defaults apply to JSON files")
+	s.Equal([]string{"tenantName"}, config.RuntimePlaceholderNames())
+	s.Equal([]string{"pending", "done"}, config.EnumPlaceholders["status"])
+}
+
+func (s *ConfigTestSuite) TestLoadConfigTOML() {
+	subDir := filepath.Join(s.tempDir, "toml")
+	s.Require().NoError(os.MkdirAll(subDir, 0755))
+	configPath := filepath.Join(subDir, "i18ngen.toml")
+	configContent := `# i18ngen configuration
+locales = ["ja", "en"]
+compound = false
+messages = '../messages/*.yaml'
+output_package = "i18n\u0031"
+params_constructor_min_fields = 1_0
+build_tags = [
+  "integration", # trailing comments and commas are allowed
+  "e2e",
+]
+
+[runtime_placeholders]
+tenantName = { runtime = true }
+"app name".runtime = true
+
+[enum_placeholders]
+status = ["pending", "done"]
+`
+	s.Require().NoError(os.WriteFile(configPath, []byte(configContent), 0644))
+
+	config, err := LoadConfig(configPath)
+	s.Require().NoError(err)
+	s.Equal([]string{"ja", "en"}, config.Locales)
+	s.False(config.Compound)
+	s.Equal("i18n1", config.OutputPackage)
+	s.Equal(10, config.ParamsMinFields)
+	s.Equal([]string{"integration", "e2e"}, config.BuildTags)
+	s.Equal(filepath.Join(s.tempDir, "messages", "*.yaml"), config.MessagesGlob)
+	s.Equal([]string{"app name", "tenantName"}, config.RuntimePlaceholderNames())
+	s.Equal([]string{"pending", "done"}, config.EnumPlaceholders["status"])
+}
+
+func (s *ConfigTestSuite) TestLoadConfigInvalidJSONAndTOML() {
+	for file, content := range map[string]string{
+		"bad.json": `{"locales": [}`,
+		"bad.toml": "sort = \"alpha\"\nsort = \"source\"\n",
+	} {
+		s.Run(file, func() {
+			configPath := filepath.Join(s.tempDir, file)
+			s.Require().NoError(os.WriteFile(configPath, []byte(content), 0644))
+
+			_, err := LoadConfig(configPath)
+			s.Require().Error(err)
+			s.Contains(err.Error(), "failed to parse config file")
+		})
+	}
+}
+
+func (s *ConfigTestSuite) TestFindConfigFile() {
+	dir := filepath.Join(s.tempDir, "discovery")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+
+	s.Equal(filepath.Join(dir, "i18ngen.yaml"), FindConfigFile(dir), "falls back to the YAML name")
+
+	for _, name := range []string{"i18ngen.toml", "i18ngen.json", "i18ngen.yml", "i18ngen.yaml"} {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(""), 0644))
+		s.Equal(filepath.Join(dir, name), FindConfigFile(dir))
+	}
+}
+
+func (s *ConfigTestSuite) TestFindConfigFileInParentDirectory() {
+	root := filepath.Join(s.tempDir, "walk")
+	nested := filepath.Join(root, "app", "internal", "handlers")
+	s.Require().NoError(os.MkdirAll(nested, 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(root, "i18ngen.json"), []byte("{}"), 0644))
+
+	found := FindConfigFile(nested)
+	s.Equal(filepath.Join(root, "i18ngen.json"), found)
+
+	// The nearest directory wins over the format order
+	s.Require().NoError(os.WriteFile(filepath.Join(root, "app", "i18ngen.toml"), []byte(""), 0644))
+	s.Equal(filepath.Join(root, "app", "i18ngen.toml"), FindConfigFile(nested))
+}
+
 func (s *ConfigTestSuite) TestConfigPathResolution() {
 	// Create a subdirectory
 	subDir := filepath.Join(s.tempDir, "subdir")
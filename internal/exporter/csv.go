@@ -0,0 +1,126 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+	"github.com/hacomono-lib/go-i18ngen/internal/utils"
+)
+
+const (
+	// CSVFileName is the name of the file written by ExportCSV
+	CSVFileName = "messages.csv"
+
+	// CSV columns preceding the locale columns
+	CSVColumnID          = "id"
+	CSVColumnForm        = "form"
+	CSVColumnDescription = "description"
+)
+
+// CSVHeader returns the header row of the translation matrix for the given locales
+func CSVHeader(locales []string) []string {
+	return append([]string{CSVColumnID, CSVColumnForm, CSVColumnDescription}, locales...)
+}
+
+// ExportCSV writes all messages as a single translation matrix into dir and returns its path
+func ExportCSV(dir string, messages []model.MessageSource, locales []string) (string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("failed to create export directory %q: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, CSVFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) // #nosec G304 - Writing the export file is intentional
+	if err != nil {
+		return "", fmt.Errorf("failed to create CSV file %q: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := WriteCSV(f, messages, locales); err != nil {
+		return "", fmt.Errorf("failed to write CSV file %q: %w", path, err)
+	}
+	return path, f.Close()
+}
+
+// WriteCSV writes the translation matrix: one row per message with one column per locale.
+//
+// Plural messages span several rows sharing the same ID, one per plural form in CLDR order,
+// with the form column naming the category. The form column is empty for other messages.
+// Templates are written verbatim, so placeholders stay as {{.field}} text.
+func WriteCSV(w io.Writer, messages []model.MessageSource, locales []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVHeader(locales)); err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		forms := pluralForms(msg)
+		if len(forms) == 0 {
+			row := []string{msg.ID, "", msg.Description}
+			for _, locale := range locales {
+				row = append(row, msg.Templates[locale])
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, form := range forms {
+			row := []string{msg.ID, form, msg.Description}
+			for _, locale := range locales {
+				row = append(row, pluralFormText(msg.RawTemplates[locale], form))
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// pluralForms returns the plural categories used by any locale of the message in CLDR order,
+// or nil when the message has no plural forms
+func pluralForms(msg model.MessageSource) []string {
+	used := map[string]bool{}
+	for _, raw := range msg.RawTemplates {
+		forms, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for form := range forms {
+			used[form] = true
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	var result []string
+	for _, category := range utils.PluralCategories {
+		if used[category] {
+			result = append(result, category)
+		}
+	}
+	return result
+}
+
+// pluralFormText returns the text of a plural form; locales without plural forms provide "other"
+func pluralFormText(raw interface{}, form string) string {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		if text, ok := v[form]; ok {
+			return fmt.Sprintf("%v", text)
+		}
+	case string:
+		if form == "other" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	messages := []model.MessageSource{
+		{
+			ID:          "EntityNotFound",
+			Description: "Shown when a lookup fails",
+			Templates: map[string]string{
+				"ja": "{{.entity}}が見つかりません",
+				"en": "{{.entity}} not found",
+			},
+			RawTemplates: map[string]interface{}{
+				"ja": "{{.entity}}が見つかりません",
+				"en": "{{.entity}} not found",
+			},
+		},
+		{
+			ID: "UserCount",
+			RawTemplates: map[string]interface{}{
+				"ja": "{{.Count}}人のユーザー",
+				"en": map[string]interface{}{
+					"other": "{{.Count}} users",
+					"one":   "{{.Count}} user",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, messages, []string{"ja", "en"}))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "form", "description", "ja", "en"},
+		{"EntityNotFound", "", "Shown when a lookup fails", "{{.entity}}が見つかりません", "{{.entity}} not found"},
+		// Plural forms span one row each in CLDR order; plain templates fill the "other" row
+		{"UserCount", "one", "", "", "{{.Count}} user"},
+		{"UserCount", "other", "", "{{.Count}}人のユーザー", "{{.Count}} users"},
+	}, rows)
+}
@@ -0,0 +1,56 @@
+// Package exporter writes parsed translations to external file formats for localization workflows.
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+)
+
+const (
+	// FormatGoI18n exports go-i18n message files (active.<locale>.yaml)
+	FormatGoI18n = "go-i18n"
+	// FormatCSV exports a translation matrix for spreadsheets (messages.csv)
+	FormatCSV = "csv"
+	// FormatXLIFF exports one XLIFF 2.0 file per target locale (messages.<locale>.xlf)
+	FormatXLIFF = "xliff"
+	// FormatGettextPOT exports a gettext template of the primary locale (messages.pot)
+	FormatGettextPOT = "gettext-pot"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatGoI18n, FormatCSV, FormatXLIFF, FormatGettextPOT}
+
+// ExportGoI18n writes one go-i18n message file per locale into dir and returns the written paths.
+// Plural forms are kept intact so the files can be loaded directly with i18n.Bundle.
+func ExportGoI18n(
+	dir string,
+	messages []templatex.MessageTemplate,
+	messageDefs []templatex.Message,
+	locales []string,
+) ([]string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create export directory %q: %w", dir, err)
+	}
+
+	messagesByLocale := templatex.BuildMessagesByLocale(messages, messageDefs, locales)
+
+	exportLocales := make([]string, 0, len(messagesByLocale))
+	for locale := range messagesByLocale {
+		exportLocales = append(exportLocales, locale)
+	}
+	sort.Strings(exportLocales)
+
+	var written []string
+	for _, locale := range exportLocales {
+		path := filepath.Join(dir, "active."+locale+".yaml")
+		if err := os.WriteFile(path, templatex.FormatMessageFile(messagesByLocale[locale]), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write go-i18n message file %q: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
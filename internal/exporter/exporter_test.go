@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportGoI18n(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+
+	messageDefs := []templatex.Message{
+		{
+			ID: "EntityNotFound",
+			Templates: map[string]string{
+				"ja": "{{.entity}}が見つかりません",
+				"en": "{{.entity}} not found",
+			},
+			RawTemplates: map[string]interface{}{
+				"ja": "{{.entity}}が見つかりません",
+				"en": "{{.entity}} not found",
+			},
+		},
+		{
+			ID: "UserCount",
+			Templates: map[string]string{
+				"ja": "{{.Count}}人のユーザー",
+				"en": "{{.Count}} users",
+			},
+			RawTemplates: map[string]interface{}{
+				"ja": "{{.Count}}人のユーザー",
+				"en": map[string]interface{}{
+					"one":   "{{.Count}} user",
+					"other": "{{.Count}} users",
+				},
+			},
+		},
+	}
+
+	written, err := ExportGoI18n(dir, nil, messageDefs, []string{"ja", "en"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "active.en.yaml"),
+		filepath.Join(dir, "active.ja.yaml"),
+	}, written)
+
+	content, err := os.ReadFile(filepath.Join(dir, "active.en.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `one: "{{.Count}} user"`)
+	assert.Contains(t, string(content), `other: "{{.Count}} users"`)
+
+	// Exported files must be loadable by go-i18n as-is
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	for _, path := range written {
+		_, err := bundle.LoadMessageFile(path)
+		require.NoError(t, err, "failed to load %s", path)
+	}
+
+	localizer := i18n.NewLocalizer(bundle, "en")
+	one, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:    "UserCount",
+		PluralCount:  1,
+		TemplateData: map[string]interface{}{"Count": 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1 user", one)
+
+	localizer = i18n.NewLocalizer(bundle, "ja")
+	ja, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:    "EntityNotFound",
+		TemplateData: map[string]interface{}{"entity": "ユーザー"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ユーザーが見つかりません", ja)
+}
@@ -0,0 +1,100 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+// GettextPOTFileName is the name of the file written by ExportGettextPOT
+const GettextPOTFileName = "messages.pot"
+
+// poEscaper escapes text for a double-quoted PO string
+var poEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// ExportGettextPOT writes a gettext template of the messages in sourceLocale into dir and returns its path
+func ExportGettextPOT(dir string, messages []model.MessageSource, sourceLocale string) (string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("failed to create export directory %q: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, GettextPOTFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) // #nosec G304 - Writing the export file is intentional
+	if err != nil {
+		return "", fmt.Errorf("failed to create POT file %q: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := WriteGettextPOT(f, messages, sourceLocale); err != nil {
+		return "", fmt.Errorf("failed to write POT file %q: %w", path, err)
+	}
+	return path, f.Close()
+}
+
+// WriteGettextPOT writes a gettext template (.pot) to bootstrap .po files with a gettext toolchain.
+//
+// Each message becomes an entry whose msgctxt is the message ID and whose msgid is the text in
+// sourceLocale, with an empty msgstr. Plural messages take their msgid from the "one" form and
+// their msgid_plural from the "other" form. The comment above a message becomes a "#." comment.
+// Messages without a text in sourceLocale are left out, since an empty msgid is reserved for the header.
+// Templates are written verbatim, so placeholders stay as {{.field}} text.
+func WriteGettextPOT(w io.Writer, messages []model.MessageSource, sourceLocale string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n\"Content-Transfer-Encoding: 8bit\\n\"\n")
+
+	for _, msg := range messages {
+		raw, ok := msg.RawTemplates[sourceLocale]
+		if !ok {
+			continue
+		}
+
+		forms := pluralForms(msg)
+		singular := msg.Templates[sourceLocale]
+		if len(forms) > 0 {
+			singular = pluralFormText(raw, "one")
+			if singular == "" {
+				singular = pluralFormText(raw, "other")
+			}
+		}
+		if singular == "" {
+			continue
+		}
+
+		fmt.Fprintln(bw)
+		if msg.Description != "" {
+			for _, line := range strings.Split(msg.Description, "\n") {
+				fmt.Fprintf(bw, "#. %s\n", line)
+			}
+		}
+		writePOString(bw, "msgctxt", msg.ID)
+		writePOString(bw, "msgid", singular)
+		if len(forms) == 0 {
+			writePOString(bw, "msgstr", "")
+			continue
+		}
+		writePOString(bw, "msgid_plural", pluralFormText(raw, "other"))
+		writePOString(bw, "msgstr[0]", "")
+		writePOString(bw, "msgstr[1]", "")
+	}
+	return bw.Flush()
+}
+
+// writePOString writes a keyword with its quoted value, splitting multi-line text after each
+// newline into continuation strings as gettext tools do
+func writePOString(w io.Writer, keyword, text string) {
+	if !strings.Contains(strings.TrimSuffix(text, "\n"), "\n") {
+		fmt.Fprintf(w, "%s \"%s\"\n", keyword, poEscaper.Replace(text))
+		return
+	}
+	fmt.Fprintf(w, "%s \"\"\n", keyword)
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" {
+			fmt.Fprintf(w, "\"%s\"\n", poEscaper.Replace(line))
+		}
+	}
+}
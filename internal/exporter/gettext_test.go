@@ -0,0 +1,67 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGettextPOT(t *testing.T) {
+	messages := []model.MessageSource{
+		{
+			ID:           "Save",
+			Description:  "Saves the profile",
+			Templates:    map[string]string{"en": "Save \"now\"", "ja": "保存"},
+			RawTemplates: map[string]interface{}{"en": "Save \"now\"", "ja": "保存"},
+		},
+		{
+			ID: "UserCount",
+			RawTemplates: map[string]interface{}{
+				"ja": "{{.Count}}人のユーザー",
+				"en": map[string]interface{}{
+					"other": "{{.Count}} users",
+					"one":   "{{.Count}} user",
+				},
+			},
+		},
+		{
+			ID:           "Footer",
+			Templates:    map[string]string{"en": "Line one\nLine two"},
+			RawTemplates: map[string]interface{}{"en": "Line one\nLine two"},
+		},
+		{
+			ID:           "JapaneseOnly",
+			Templates:    map[string]string{"ja": "日本語のみ"},
+			RawTemplates: map[string]interface{}{"ja": "日本語のみ"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGettextPOT(&buf, messages, "en"))
+	assert.Equal(t, `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+
+#. Saves the profile
+msgctxt "Save"
+msgid "Save \"now\""
+msgstr ""
+
+msgctxt "UserCount"
+msgid "{{.Count}} user"
+msgid_plural "{{.Count}} users"
+msgstr[0] ""
+msgstr[1] ""
+
+msgctxt "Footer"
+msgid ""
+"Line one\n"
+"Line two"
+msgstr ""
+`, buf.String())
+}
@@ -0,0 +1,156 @@
+package exporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+// xliffNamespace is the namespace of XLIFF 2.0 documents
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+// Note categories written to the <notes> of each unit
+const (
+	XLIFFNoteDescription = "description"
+	XLIFFNoteContext     = "context"
+	XLIFFNoteScreenshot  = "screenshot"
+	XLIFFNoteCountHint   = "count_hint"
+)
+
+// XLIFFFileName returns the name of the XLIFF file written by ExportXLIFF for targetLocale
+func XLIFFFileName(targetLocale string) string {
+	return "messages." + targetLocale + ".xlf"
+}
+
+type xliffDocument struct {
+	XMLName xml.Name  `xml:"xliff"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	SrcLang string    `xml:"srcLang,attr"`
+	TrgLang string    `xml:"trgLang,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	ID    string      `xml:"id,attr"`
+	Units []xliffUnit `xml:"unit"`
+}
+
+type xliffUnit struct {
+	ID       string         `xml:"id,attr"`
+	Notes    *xliffNotes    `xml:"notes,omitempty"`
+	Segments []xliffSegment `xml:"segment"`
+}
+
+type xliffNotes struct {
+	Notes []xliffNote `xml:"note"`
+}
+
+type xliffNote struct {
+	Category string `xml:"category,attr"`
+	Text     string `xml:",chardata"`
+}
+
+type xliffSegment struct {
+	ID     string `xml:"id,attr,omitempty"`
+	Source string `xml:"source"`
+	Target string `xml:"target,omitempty"`
+}
+
+// ExportXLIFF writes one XLIFF 2.0 file per locale other than sourceLocale into dir and returns the written paths
+func ExportXLIFF(dir string, messages []model.MessageSource, locales []string, sourceLocale string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create export directory %q: %w", dir, err)
+	}
+
+	var written []string
+	for _, locale := range locales {
+		if locale == sourceLocale {
+			continue
+		}
+		path := filepath.Join(dir, XLIFFFileName(locale))
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) // #nosec G304 - Writing the export file is intentional
+		if err != nil {
+			return nil, fmt.Errorf("failed to create XLIFF file %q: %w", path, err)
+		}
+		if err := WriteXLIFF(f, messages, sourceLocale, locale); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to write XLIFF file %q: %w", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// WriteXLIFF writes an XLIFF 2.0 document translating messages from sourceLocale to targetLocale.
+//
+// Each message becomes a unit whose notes carry its description and translator context.
+// Plural messages get one segment per plural form in CLDR order, identified by the category.
+// Templates are written verbatim, so placeholders stay as {{.field}} text.
+func WriteXLIFF(w io.Writer, messages []model.MessageSource, sourceLocale, targetLocale string) error {
+	doc := xliffDocument{
+		Xmlns:   xliffNamespace,
+		Version: "2.0",
+		SrcLang: sourceLocale,
+		TrgLang: targetLocale,
+		File:    xliffFile{ID: "messages"},
+	}
+
+	for _, msg := range messages {
+		unit := xliffUnit{ID: msg.ID, Notes: xliffUnitNotes(msg)}
+		forms := pluralForms(msg)
+		if len(forms) == 0 {
+			unit.Segments = []xliffSegment{{
+				Source: msg.Templates[sourceLocale],
+				Target: msg.Templates[targetLocale],
+			}}
+		}
+		for _, form := range forms {
+			unit.Segments = append(unit.Segments, xliffSegment{
+				ID:     form,
+				Source: pluralFormText(msg.RawTemplates[sourceLocale], form),
+				Target: pluralFormText(msg.RawTemplates[targetLocale], form),
+			})
+		}
+		doc.File.Units = append(doc.File.Units, unit)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// xliffUnitNotes returns the notes of a message, or nil when it has no description, context or count hint
+func xliffUnitNotes(msg model.MessageSource) *xliffNotes {
+	var notes []xliffNote
+	if msg.Description != "" {
+		notes = append(notes, xliffNote{Category: XLIFFNoteDescription, Text: msg.Description})
+	}
+	if msg.Context.Note != "" {
+		notes = append(notes, xliffNote{Category: XLIFFNoteContext, Text: msg.Context.Note})
+	}
+	if msg.Context.Screenshot != "" {
+		notes = append(notes, xliffNote{Category: XLIFFNoteScreenshot, Text: msg.Context.Screenshot})
+	}
+	if msg.CountHint != "" {
+		notes = append(notes, xliffNote{Category: XLIFFNoteCountHint, Text: msg.CountHint})
+	}
+	if len(notes) == 0 {
+		return nil
+	}
+	return &xliffNotes{Notes: notes}
+}
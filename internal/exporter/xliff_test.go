@@ -0,0 +1,80 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteXLIFF(t *testing.T) {
+	messages := []model.MessageSource{
+		{
+			ID:          "Save",
+			Description: "Saves the profile",
+			Context: model.MessageContext{
+				Note:       "Button label in the profile editor",
+				Screenshot: "https://example.com/profile.png",
+			},
+			Templates:    map[string]string{"ja": "保存", "en": "Save"},
+			RawTemplates: map[string]interface{}{"ja": "保存", "en": "Save"},
+		},
+		{
+			ID:        "UserCount",
+			CountHint: "usually 1-100",
+			RawTemplates: map[string]interface{}{
+				"ja": "{{.Count}}人のユーザー",
+				"en": map[string]interface{}{
+					"other": "{{.Count}} users",
+					"one":   "{{.Count}} user",
+				},
+			},
+		},
+		{
+			ID:           "Untranslated",
+			Templates:    map[string]string{"ja": "未翻訳 & <b>"},
+			RawTemplates: map[string]interface{}{"ja": "未翻訳 & <b>"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLIFF(&buf, messages, "ja", "en"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="ja" trgLang="en">
+  <file id="messages">
+    <unit id="Save">
+      <notes>
+        <note category="description">Saves the profile</note>
+        <note category="context">Button label in the profile editor</note>
+        <note category="screenshot">https://example.com/profile.png</note>
+      </notes>
+      <segment>
+        <source>保存</source>
+        <target>Save</target>
+      </segment>
+    </unit>
+    <unit id="UserCount">
+      <notes>
+        <note category="count_hint">usually 1-100</note>
+      </notes>
+      <segment id="one">
+        <source></source>
+        <target>{{.Count}} user</target>
+      </segment>
+      <segment id="other">
+        <source>{{.Count}}人のユーザー</source>
+        <target>{{.Count}} users</target>
+      </segment>
+    </unit>
+    <unit id="Untranslated">
+      <segment>
+        <source>未翻訳 &amp; &lt;b&gt;</source>
+      </segment>
+    </unit>
+  </file>
+</xliff>
+`, buf.String())
+}
@@ -0,0 +1,181 @@
+// Package formatter rewrites message files into a canonical layout to keep translation diffs small.
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/utils"
+	"github.com/hacomono-lib/go-i18ngen/internal/yamledit"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatMessages formats the content of a YAML message file.
+//
+// Message IDs are sorted alphabetically, locales follow the given order (unknown locales
+// are appended alphabetically), plural forms follow CLDR order and string values are
+// double-quoted. Comments are preserved because the rewrite operates on yaml.v3 nodes.
+func FormatMessages(content []byte, locales []string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	// Nothing to format for empty documents
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return content, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("message file must be a mapping of message IDs, got %s", nodeKindName(root.Kind))
+	}
+
+	sortMapping(root, alphabeticalOrder)
+	for i := 1; i < len(root.Content); i += 2 {
+		formatMessageNode(root.Content[i], locales)
+	}
+
+	formatted, err := yamledit.Encode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return formatted, nil
+}
+
+// FormatFile formats a message file in place and reports whether its content changed
+func FormatFile(path string, locales []string) (bool, error) {
+	content, err := os.ReadFile(path) // #nosec G304 - Reading message files is intentional
+	if err != nil {
+		return false, fmt.Errorf("failed to read message file %q: %w", path, err)
+	}
+
+	formatted, err := FormatMessages(content, locales)
+	if err != nil {
+		return false, fmt.Errorf("failed to format message file %q: %w", path, err)
+	}
+
+	if bytes.Equal(content, formatted) {
+		return false, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat message file %q: %w", path, err)
+	}
+	if err := os.WriteFile(path, formatted, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write message file %q: %w", path, err)
+	}
+	return true, nil
+}
+
+// formatMessageNode formats the locale mapping of a single message
+func formatMessageNode(node *yaml.Node, locales []string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		sortMapping(node, localeOrder(locales))
+		for i := 1; i < len(node.Content); i += 2 {
+			value := node.Content[i]
+			if value.Kind == yaml.MappingNode {
+				// Plural forms (one, other, ...)
+				sortMapping(value, pluralOrder)
+				for j := 1; j < len(value.Content); j += 2 {
+					quoteScalar(value.Content[j])
+				}
+				continue
+			}
+			quoteScalar(value)
+		}
+	case yaml.ScalarNode:
+		// Simple format: message ID -> template
+		quoteScalar(node)
+	case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
+		// Leave unexpected shapes untouched; the parser reports them during generation
+	}
+}
+
+// quoteScalar applies consistent double quoting to single-line string values
+func quoteScalar(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return
+	}
+	// Keep block scalars for multi-line text, they are easier to review than escaped newlines
+	if node.Style == yaml.LiteralStyle || node.Style == yaml.FoldedStyle {
+		return
+	}
+	node.Style = yaml.DoubleQuotedStyle
+}
+
+// keyRank returns a sort rank for a key; keys with equal rank are ordered alphabetically
+type keyRank func(key string) int
+
+func alphabeticalOrder(string) int {
+	return 0
+}
+
+func localeOrder(locales []string) keyRank {
+	ranks := make(map[string]int, len(locales))
+	for i, locale := range locales {
+		ranks[locale] = i
+	}
+	return func(key string) int {
+		if rank, ok := ranks[key]; ok {
+			return rank
+		}
+		return len(locales)
+	}
+}
+
+func pluralOrder(key string) int {
+	if index := utils.PluralCategoryIndex(key); index != -1 {
+		return index
+	}
+	return len(utils.PluralCategories)
+}
+
+// sortMapping reorders the key/value pairs of a mapping node in place
+func sortMapping(node *yaml.Node, rank keyRank) {
+	type pair struct {
+		key   *yaml.Node
+		value *yaml.Node
+	}
+
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{key: node.Content[i], value: node.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		rankI, rankJ := rank(pairs[i].key.Value), rank(pairs[j].key.Value)
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return pairs[i].key.Value < pairs[j].key.Value
+	})
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, p := range pairs {
+		content = append(content, p.key, p.value)
+	}
+	node.Content = content
+}
+
+func nodeKindName(kind yaml.Kind) string {
+	switch kind {
+	case yaml.DocumentNode:
+		return "document"
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.ScalarNode:
+		return "scalar"
+	case yaml.AliasNode:
+		return "alias"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,100 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMessages(t *testing.T) {
+	t.Run("sorts message IDs, locales and plural forms", func(t *testing.T) {
+		input := `UserCount:
+  en:
+    other: '{{.Count}} users'
+    one: '{{.Count}} user'
+  ja: '{{.Count}}人のユーザー'
+EntityNotFound:
+  en: '{{.entity}} not found'
+  fr: '{{.entity}} introuvable'
+  ja: '{{.entity}}が見つかりません'
+`
+		expected := `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+  fr: "{{.entity}} introuvable"
+UserCount:
+  ja: "{{.Count}}人のユーザー"
+  en:
+    one: "{{.Count}} user"
+    other: "{{.Count}} users"
+`
+		formatted, err := FormatMessages([]byte(input), []string{"ja", "en"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(formatted))
+	})
+
+	t.Run("preserves comments", func(t *testing.T) {
+		input := `# Greeting shown on the dashboard
+Welcome:
+  en: "Welcome"  # short form
+  ja: "ようこそ"
+`
+		formatted, err := FormatMessages([]byte(input), []string{"ja", "en"})
+		require.NoError(t, err)
+		assert.Contains(t, string(formatted), "# Greeting shown on the dashboard")
+		assert.Contains(t, string(formatted), "# short form")
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		input := `B:
+  en: b
+A:
+  en:
+    other: as
+    one: a
+`
+		first, err := FormatMessages([]byte(input), []string{"en"})
+		require.NoError(t, err)
+		second, err := FormatMessages(first, []string{"en"})
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(second))
+	})
+
+	t.Run("quotes simple format values", func(t *testing.T) {
+		formatted, err := FormatMessages([]byte("Hello: hello\n"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello: \"hello\"\n", string(formatted))
+	})
+
+	t.Run("keeps empty documents", func(t *testing.T) {
+		formatted, err := FormatMessages([]byte(""), nil)
+		require.NoError(t, err)
+		assert.Empty(t, formatted)
+	})
+
+	t.Run("rejects non-mapping documents", func(t *testing.T) {
+		_, err := FormatMessages([]byte("- a\n- b\n"), nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a mapping")
+	})
+}
+
+func TestFormatFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("B:\n  en: b\nA:\n  en: a\n"), 0644))
+
+	changed, err := FormatFile(path, []string{"en"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "A:\n  en: \"a\"\nB:\n  en: \"b\"\n", string(content))
+
+	changed, err = FormatFile(path, []string{"en"})
+	require.NoError(t, err)
+	assert.False(t, changed, "already formatted files should not be rewritten")
+}
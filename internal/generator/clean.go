@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+)
+
+// generatedHeaderPattern matches the standard header of generated Go files (see `go help generate`),
+// and its YAML comment form used by embedded data files
+var generatedHeaderPattern = regexp.MustCompile(`^(//|#) Code generated .* DO NOT EDIT\.$`)
+
+// OutputFiles returns the files generation may write into outputDir, including the data files
+// of embed_data present for any locale
+func OutputFiles(outputDir string) []string {
+	files := []string{
+		filepath.Join(outputDir, OutputFileName),
+		filepath.Join(outputDir, templatex.SmokeTestFileName),
+		filepath.Join(outputDir, ExampleDir, templatex.UsageExampleFileName),
+	}
+	dataFiles, _ := filepath.Glob(filepath.Join(outputDir, templatex.MessageDataFileName("*")))
+	files = append(files, dataFiles...)
+	return append(files, filepath.Join(outputDir, templatex.PlaceholderDataFileName))
+}
+
+// Clean removes the generated files present in outputDir and returns their paths.
+//
+// Every file must carry the "Code generated ... DO NOT EDIT." header; a file without it is
+// reported as an error before anything is removed, so hand-written files are never deleted.
+// With dryRun set, the files are only listed.
+func Clean(outputDir string, dryRun bool) ([]string, error) {
+	var found []string
+	for _, path := range OutputFiles(outputDir) {
+		generated, err := isGeneratedFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !generated {
+			return nil, fmt.Errorf(
+				"refusing to remove %q: it does not carry the generated code header\n\nSuggestions:\n"+
+					"  - Check that output_dir points to the generated package\n"+
+					"  - Remove the file manually if it is no longer needed",
+				path)
+		}
+		found = append(found, path)
+	}
+	if dryRun {
+		return found, nil
+	}
+
+	for _, path := range found {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove %q: %w", path, err)
+		}
+	}
+	// The example package only holds generated code; drop its directory once empty
+	_ = os.Remove(filepath.Join(outputDir, ExampleDir))
+	return found, nil
+}
+
+// isGeneratedFile reports whether a Go file starts with the generated code header,
+// allowing for blank lines and other comments before the package clause
+func isGeneratedFile(path string) (bool, error) {
+	f, err := os.Open(path) // #nosec G304 - Inspecting generated files is intentional
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if generatedHeaderPattern.MatchString(line) {
+			return true, nil
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	return false, nil
+}
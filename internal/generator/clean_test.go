@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generatedSource = "// Code generated by i18ngen. DO NOT EDIT.\npackage i18n\n"
+
+func TestClean(t *testing.T) {
+	t.Run("removes generated files", func(t *testing.T) {
+		outputDir := t.TempDir()
+		mainFile := filepath.Join(outputDir, OutputFileName)
+		exampleFile := filepath.Join(outputDir, ExampleDir, "usage_example.go")
+		handWritten := filepath.Join(outputDir, "i18n.go")
+		require.NoError(t, os.MkdirAll(filepath.Dir(exampleFile), 0755))
+		require.NoError(t, os.WriteFile(mainFile, []byte(generatedSource), 0644))
+		require.NoError(t, os.WriteFile(exampleFile, []byte(generatedSource), 0644))
+		require.NoError(t, os.WriteFile(handWritten, []byte("package i18n\n"), 0644))
+
+		removed, err := Clean(outputDir, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{mainFile, exampleFile}, removed)
+		assert.NoFileExists(t, mainFile)
+		assert.NoDirExists(t, filepath.Join(outputDir, ExampleDir))
+		assert.FileExists(t, handWritten, "files outside the generated set are kept")
+	})
+
+	t.Run("dry run keeps files", func(t *testing.T) {
+		outputDir := t.TempDir()
+		mainFile := filepath.Join(outputDir, OutputFileName)
+		require.NoError(t, os.WriteFile(mainFile, []byte(generatedSource), 0644))
+
+		removed, err := Clean(outputDir, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{mainFile}, removed)
+		assert.FileExists(t, mainFile)
+	})
+
+	t.Run("accepts build constraints after the header", func(t *testing.T) {
+		outputDir := t.TempDir()
+		mainFile := filepath.Join(outputDir, OutputFileName)
+		require.NoError(t, os.WriteFile(mainFile, []byte("// Code generated by i18ngen. DO NOT EDIT.\n\n//go:build prod\n\npackage i18n\n"), 0644))
+
+		removed, err := Clean(outputDir, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{mainFile}, removed)
+	})
+
+	t.Run("refuses files without the generated header", func(t *testing.T) {
+		outputDir := t.TempDir()
+		mainFile := filepath.Join(outputDir, OutputFileName)
+		exampleFile := filepath.Join(outputDir, ExampleDir, "usage_example.go")
+		require.NoError(t, os.MkdirAll(filepath.Dir(exampleFile), 0755))
+		require.NoError(t, os.WriteFile(mainFile, []byte(generatedSource), 0644))
+		require.NoError(t, os.WriteFile(exampleFile, []byte("// Hand-written example\npackage example\n"), 0644))
+
+		_, err := Clean(outputDir, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not carry the generated code header")
+		assert.FileExists(t, mainFile, "nothing is removed when any file is refused")
+		assert.FileExists(t, exampleFile)
+	})
+
+	t.Run("nothing to clean", func(t *testing.T) {
+		removed, err := Clean(t.TempDir(), false)
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+	})
+}
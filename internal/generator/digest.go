@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// inputsDigestPrefix starts the header line recording the digest of the inputs the output was generated from
+const inputsDigestPrefix = "// i18ngen inputs: "
+
+// outputMatchesInputs reports whether the generated code records the digest of the current
+// inputs, so it was generated from them and not from an earlier commit, a dirty working tree
+// or other flags. --if-stale and --since-git write the digest and check it before skipping.
+func outputMatchesInputs(cfg *config.Config) bool {
+	digest, err := inputsDigest(cfg)
+	if err != nil {
+		return false
+	}
+	f, err := os.Open(filepath.Join(cfg.OutputDir, OutputFileName)) // #nosec G304 - Reading the generated file is intentional
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	// The digest follows the "Code generated" line at the top of the file
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < 2 && scanner.Scan(); i++ {
+		if recorded, ok := strings.CutPrefix(scanner.Text(), inputsDigestPrefix); ok {
+			return recorded == digest
+		}
+	}
+	return false
+}
+
+// inputsDigest returns the SHA-256 digest over the effective configuration, which includes
+// flags overriding the config file, and over the path and content of the config file and of
+// every message and placeholder file, in glob order. Paths are taken relative to the directory
+// of the config file, or the working directory without one, so the digest survives moving the project.
+func inputsDigest(cfg *config.Config) (string, error) {
+	base := "."
+	var files []string
+	if cfg.ConfigPath != "" {
+		base = filepath.Dir(cfg.ConfigPath)
+		files = append(files, cfg.ConfigPath)
+	}
+	for _, pattern := range []string{cfg.MessagesGlob, cfg.PlaceholdersGlob} {
+		if pattern == "" {
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", err
+		}
+		files = append(files, matches...)
+	}
+
+	effective := *cfg
+	effective.MessagesGlob = relativePath(base, cfg.MessagesGlob)
+	effective.PlaceholdersGlob = relativePath(base, cfg.PlaceholdersGlob)
+	effective.OutputDir = relativePath(base, cfg.OutputDir)
+	settings, err := yaml.Marshal(&effective)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	// Length prefixes keep the boundaries between the configuration and the files unambiguous
+	fmt.Fprintf(hash, "%d:", len(settings))
+	hash.Write(settings)
+	for _, file := range files {
+		content, err := os.ReadFile(file) // #nosec G304 - Reading the configured inputs is intentional
+		if err != nil {
+			return "", err
+		}
+		name := filepath.ToSlash(relativePath(base, file))
+		fmt.Fprintf(hash, "%d:%s%d:", len(name), name, len(content))
+		hash.Write(content)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// relativePath returns path relative to base, or path itself when it cannot be made relative
+func relativePath(base, path string) string {
+	if path == "" {
+		return path
+	}
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,41 @@
+package generator
+
+import "errors"
+
+var (
+	// ErrInvalidConfig classifies errors caused by the configuration file or flags
+	ErrInvalidConfig = errors.New("invalid configuration")
+	// ErrInvalidInput classifies errors caused by message or placeholder files failing validation
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// classifiedError tags an error with one of the sentinel classes without changing its message,
+// so callers can branch on the cause with errors.Is
+type classifiedError struct {
+	err   error
+	class error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.err, e.class}
+}
+
+// ConfigError classifies err as an ErrInvalidConfig; nil stays nil
+func ConfigError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{err: err, class: ErrInvalidConfig}
+}
+
+// InputError classifies err as an ErrInvalidInput; nil stays nil
+func InputError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{err: err, class: ErrInvalidInput}
+}
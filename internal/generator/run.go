@@ -3,15 +3,44 @@ package generator
 
 import (
 	"fmt"
+	"go/build/constraint"
+	"go/token"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/hacomono-lib/go-i18ngen/internal/config"
 	"github.com/hacomono-lib/go-i18ngen/internal/model"
 	"github.com/hacomono-lib/go-i18ngen/internal/parser"
 	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+	"github.com/hacomono-lib/go-i18ngen/internal/utils"
 )
 
+// Corpus holds the parsed sources and the definitions built from them
+type Corpus struct {
+	Messages             []model.MessageSource
+	Placeholders         []model.PlaceholderSource
+	Definitions          *model.Definitions
+	MessageTemplates     []templatex.MessageTemplate
+	PlaceholderTemplates []templatex.PlaceholderTemplate
+	PrimaryLocale        string
+}
+
+const (
+	// OutputFileName is the name of the generated Go file in the output directory
+	OutputFileName = "i18n.gen.go"
+	// ExampleDir is the directory, relative to the output directory, receiving the usage example package
+	ExampleDir = "example"
+	// StdinName identifies messages read from standard input in errors and source locations
+	StdinName = "<stdin>"
+	// LocalePattern in the output directory generates a separate package per locale
+	LocalePattern = "{locale}"
+)
+
+// Run generates the code for cfg and writes it, together with the optional usage example,
+// smoke test and trace, to cfg.OutputDir
 func Run(cfg *config.Config) (returnErr error) {
 	// Add panic recovery mechanism to prevent unexpected crashes
 	defer func() {
@@ -24,120 +53,765 @@ func Run(cfg *config.Config) (returnErr error) {
 	if cfg == nil {
 		return fmt.Errorf("configuration cannot be nil")
 	}
+	if cfg.OutputDir == "" {
+		return ConfigError(fmt.Errorf("output directory cannot be empty"))
+	}
+	if strings.Contains(cfg.OutputDir, LocalePattern) {
+		return runPerLocale(cfg)
+	}
 
-	// Validate required configuration fields
-	if cfg.MessagesGlob == "" {
-		return fmt.Errorf("messages glob pattern cannot be empty")
+	if cfg.IfStale {
+		if _, err := validateOutput(cfg); err != nil {
+			return ConfigError(err)
+		}
+		upToDate, err := outputsUpToDate(cfg)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			return nil
+		}
 	}
-	if cfg.PlaceholdersGlob == "" {
-		return fmt.Errorf("placeholders glob pattern cannot be empty")
+	if cfg.SinceGit {
+		if _, err := validateOutput(cfg); err != nil {
+			return ConfigError(err)
+		}
+		if inputsUnchangedInGit(cfg) && outputMatchesInputs(cfg) {
+			return nil
+		}
 	}
-	if cfg.OutputDir == "" {
-		return fmt.Errorf("output directory cannot be empty")
+
+	result, err := generate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if mkdirErr := os.MkdirAll(cfg.OutputDir, 0750); mkdirErr != nil {
+		return fmt.Errorf(
+			"failed to create output directory %q: %w\n\nSuggestions:\n"+
+				"  - Check directory permissions\n"+
+				"  - Ensure parent directories exist\n"+
+				"  - Verify the path is not read-only",
+			cfg.OutputDir, mkdirErr)
+	}
+
+	outputFile := filepath.Join(cfg.OutputDir, OutputFileName)
+	if err := os.WriteFile(outputFile, result.code, 0600); err != nil {
+		return fmt.Errorf(
+			"failed to write generated code to %q:\n  %w\n\nSuggestions:\n"+
+				"  - Check output directory permissions\n"+
+				"  - Check for disk space availability",
+			outputFile, err)
+	}
+	for name, data := range result.dataFiles {
+		dataFile := filepath.Join(cfg.OutputDir, name)
+		if err := os.WriteFile(dataFile, data, 0600); err != nil {
+			return fmt.Errorf("failed to write embedded data to %q: %w", dataFile, err)
+		}
+	}
+
+	if cfg.PackagePath != "" {
+		examplePath := filepath.Join(cfg.OutputDir, ExampleDir, templatex.UsageExampleFileName)
+		if err := templatex.RenderUsageExample(
+			examplePath,
+			cfg.OutputPackage,
+			cfg.PackagePath,
+			result.corpus.PrimaryLocale,
+			result.buildConstraint,
+			result.corpus.Definitions.Placeholders,
+			result.corpus.Definitions.Messages,
+		); err != nil {
+			return fmt.Errorf("failed to render usage example to %q:\n  %w", examplePath, err)
+		}
+	}
+
+	if cfg.OutputTest {
+		testPath := filepath.Join(cfg.OutputDir, templatex.SmokeTestFileName)
+		if err := templatex.RenderSmokeTest(
+			testPath,
+			cfg.OutputPackage,
+			result.corpus.PrimaryLocale,
+			result.buildConstraint,
+			result.corpus.Definitions.Placeholders,
+			result.corpus.Definitions.Messages,
+		); err != nil {
+			return fmt.Errorf("failed to render smoke test to %q:\n  %w", testPath, err)
+		}
+	}
+
+	if cfg.Trace {
+		if err := WriteTrace(filepath.Join(cfg.OutputDir, TraceFileName), result.corpus); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runPerLocale runs the generation once per configured locale, each into cfg.OutputDir with
+// {locale} replaced by the locale and with only that locale, so every locale gets its own package
+func runPerLocale(cfg *config.Config) error {
+	if cfg.AutofillFrom != "" {
+		return ConfigError(fmt.Errorf(
+			"output directory %q contains %s, but autofill_from cannot copy texts into a package holding one locale\n\nSuggestions:\n"+
+				"  - Remove autofill_from and translate every message in every locale\n"+
+				"  - Use an output directory without %s",
+			cfg.OutputDir, LocalePattern, LocalePattern))
 	}
 	if len(cfg.Locales) == 0 {
-		return fmt.Errorf("no locales specified in configuration")
+		return ConfigError(fmt.Errorf("output directory %q contains %s, but no locales are configured", cfg.OutputDir, LocalePattern))
+	}
+	for i, dir := range OutputDirs(cfg.OutputDir, cfg.Locales) {
+		localeCfg := *cfg
+		localeCfg.OutputDir = dir
+		localeCfg.Locales = []string{cfg.Locales[i]}
+		localeCfg.LocalesOnly = true
+		// A primary locale is the fallback within one package, so each package falls back to its own locale
+		localeCfg.PrimaryLocale = ""
+		if err := Run(&localeCfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OutputDirs returns the directories generation writes for outputDir: one per locale, in the
+// order of locales, when outputDir contains {locale}, and outputDir itself otherwise
+func OutputDirs(outputDir string, locales []string) []string {
+	if !strings.Contains(outputDir, LocalePattern) {
+		return []string{outputDir}
+	}
+	dirs := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		dirs = append(dirs, strings.ReplaceAll(outputDir, LocalePattern, locale))
+	}
+	return dirs
+}
+
+// RunTo generates the code for cfg and writes it to w instead of cfg.OutputDir.
+// The usage example, smoke test and trace are not produced.
+func RunTo(cfg *config.Config, w io.Writer) error {
+	code, err := Generate(cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(code); err != nil {
+		return fmt.Errorf("failed to write generated code: %w", err)
+	}
+	return nil
+}
+
+// Generate returns the formatted Go source generated for cfg without writing any file
+func Generate(cfg *config.Config) (code []byte, returnErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			returnErr = fmt.Errorf("unexpected panic occurred during generation: %v", r)
+		}
+	}()
+
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+	if cfg.DataFiles() {
+		return nil, ConfigError(fmt.Errorf(
+			"data_layout %q writes data files next to the generated code and cannot be used when only the code is written\n\n"+
+				"Suggestions:\n"+
+				"  - Use data_layout %q when generating from stdin",
+			cfg.DataLayout, config.DataLayoutInline))
+	}
+	result, err := generate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return result.code, nil
+}
+
+// generation is the rendered source together with the state Run needs for its extra outputs
+type generation struct {
+	code            []byte
+	corpus          *Corpus
+	buildConstraint string
+	// dataFiles are the data files written next to the code, keyed by name, when cfg.DataFiles() is set
+	dataFiles map[string][]byte
+}
+
+// generate loads the sources of cfg and renders the go-i18n code from them
+func generate(cfg *config.Config) (*generation, error) {
+	buildConstraint, err := validateOutput(cfg)
+	if err != nil {
+		return nil, ConfigError(err)
+	}
+
+	corpus, err := Load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFieldTypes(corpus.Definitions); err != nil {
+		return nil, err
+	}
+	if err := checkMessageBackends(cfg, corpus.Definitions); err != nil {
+		return nil, err
+	}
+
+	tc := templateConfig(cfg, buildConstraint, corpus.Definitions)
+	if cfg.SinceGit || cfg.IfStale {
+		// Unreadable inputs leave the digest out, so the next run regenerates
+		tc.InputsDigest, _ = inputsDigest(cfg)
+	}
+	code, err := templatex.GenerateGoI18nWithConfig(
+		cfg.OutputPackage,
+		corpus.PrimaryLocale,
+		corpus.MessageTemplates,
+		corpus.PlaceholderTemplates,
+		corpus.Definitions.Placeholders,
+		corpus.Definitions.Messages,
+		cfg.Locales,
+		tc,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to render go-i18n generated code:\n  %w\n\nSuggestions:\n"+
+				"  - Verify package name is valid\n"+
+				"  - Ensure templates generate valid Go code",
+			err)
+	}
+	result := &generation{code: code, corpus: corpus, buildConstraint: buildConstraint}
+	if cfg.DataFiles() {
+		messagesByLocale := templatex.BuildMessagesByLocale(corpus.MessageTemplates, corpus.Definitions.Messages, cfg.Locales)
+		result.dataFiles, err = templatex.DataFiles(messagesByLocale, corpus.PlaceholderTemplates)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.DataLayout == config.DataLayoutExternal {
+			// Placeholder texts stay inline; only messages are loaded at runtime
+			delete(result.dataFiles, templatex.PlaceholderDataFileName)
+		}
+	}
+	return result, nil
+}
+
+// checkFieldTypes ensures every message field is typed with a placeholder type that is generated,
+// so an inconsistency in the definitions fails here instead of as a compile error in the user's package
+func checkFieldTypes(defs *model.Definitions) error {
+	generated := make(map[string]bool, len(defs.Placeholders))
+	for _, ph := range defs.Placeholders {
+		generated[ph.StructName] = true
+	}
+	for _, msg := range defs.Messages {
+		for _, field := range msg.Fields {
+			if field.PlainValue || generated[field.Type] {
+				continue
+			}
+			return fmt.Errorf(
+				"internal error: field %s of message %q has type %s, which is not generated:\n"+
+					"  this is a bug in i18ngen, please report it together with the message definition",
+				field.FieldName, msg.ID, field.Type)
+		}
+	}
+	return nil
+}
+
+// WarningCounter is implemented by Warnings writers that count the warnings written to them;
+// WarningEmitted is called once per warning, however many lines the warning spans
+type WarningCounter interface {
+	WarningEmitted()
+}
+
+// warnf reports a problem that fails generation only in strict mode
+func warnf(cfg *config.Config, format string, args ...interface{}) {
+	if cfg.Warnings == nil {
+		return
+	}
+	fmt.Fprintf(cfg.Warnings, "warning: "+format+"\n", args...)
+	if counter, ok := cfg.Warnings.(WarningCounter); ok {
+		counter.WarningEmitted()
+	}
+}
+
+// fieldLocales returns the order in which message templates are tried for field extraction:
+// the primary locale, then the configured locales
+func fieldLocales(cfg *config.Config) []string {
+	return append([]string{cfg.GetPrimaryLocale()}, cfg.Locales...)
+}
+
+// parseMessages parses the message document of cfg.MessagesReader, or the files matching cfg.MessagesGlob
+func parseMessages(cfg *config.Config) ([]model.MessageSource, error) {
+	if cfg.MessagesReader != nil {
+		messages, err := parser.ParseMessagesReader(cfg.MessagesReader, StdinName, cfg.SuffixSeparator, fieldLocales(cfg))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to parse messages from %s:\n  %w\n\nSuggestions:\n"+
+					"  - Pipe a single YAML or JSON message document\n"+
+					"  - Ensure templates don't exceed complexity limits",
+				StdinName, err)
+		}
+		return messages, nil
 	}
 
 	// Check message files exist
 	messageFiles, globErr := filepath.Glob(cfg.MessagesGlob)
 	if globErr != nil {
-		return fmt.Errorf("invalid messages glob pattern %q: %w", cfg.MessagesGlob, globErr)
+		return nil, fmt.Errorf("invalid messages glob pattern %q: %w", cfg.MessagesGlob, globErr)
 	}
 
 	if len(messageFiles) == 0 {
-		return fmt.Errorf("no message files found matching pattern %q", cfg.MessagesGlob)
+		return nil, fmt.Errorf("no message files found matching pattern %q", cfg.MessagesGlob)
 	}
 
-	// Parse messages and placeholders with enhanced error context
-	messages, err := parser.ParseMessages(cfg.MessagesGlob)
+	// Parse messages with enhanced error context
+	messages, err := parser.ParseMessagesParallel(cfg.MessagesGlob, cfg.SuffixSeparator, fieldLocales(cfg), cfg.MaxParallel, cfg.InputFormat)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"failed to parse message files from pattern %q:\n  %w\n\nSuggestions:\n"+
 				"  - Check that message files exist and have valid YAML syntax\n"+
 				"  - Verify glob pattern matches your file structure\n"+
 				"  - Ensure templates don't exceed complexity limits",
 			cfg.MessagesGlob, err)
 	}
+	return messages, nil
+}
 
-	placeholders, err := parser.ParsePlaceholders(cfg.PlaceholdersGlob, cfg.Locales, cfg.Compound)
+// validateOutput checks the settings shaping the generated file and returns its build constraint
+func validateOutput(cfg *config.Config) (string, error) {
+	if err := utils.ValidatePackageName(cfg.OutputPackage); err != nil {
+		return "", fmt.Errorf(
+			"%w\n\nSuggestions:\n"+
+				"  - Set output_package in the config file or pass --package\n"+
+				"  - Use a lowercase name such as %q",
+			err, "i18n")
+	}
+	buildConstraint, err := combineBuildTags(cfg.BuildTags)
 	if err != nil {
-		return fmt.Errorf(
+		return "", fmt.Errorf(
+			"%w\n\nSuggestions:\n"+
+				"  - Use build tag names such as %q or expressions such as %q\n"+
+				"  - List each tag as a separate build_tags entry; they are combined with &&",
+			err, "prod", "!debug")
+	}
+	return buildConstraint, nil
+}
+
+// validateConfig checks the configuration settings used to load sources and build definitions
+func validateConfig(cfg *config.Config) error {
+	if cfg.MessagesGlob == "" && cfg.MessagesReader == nil {
+		return fmt.Errorf("messages glob pattern cannot be empty")
+	}
+	// Placeholders are optional for piped messages, which are often self-contained
+	if cfg.PlaceholdersGlob == "" && cfg.MessagesReader == nil {
+		return fmt.Errorf("placeholders glob pattern cannot be empty")
+	}
+	if len(cfg.Locales) == 0 {
+		return fmt.Errorf("no locales specified in configuration")
+	}
+	if cfg.PrimaryLocale != "" && !slices.Contains(cfg.Locales, cfg.PrimaryLocale) {
+		return fmt.Errorf("primary locale %q is not one of the configured locales %v", cfg.PrimaryLocale, cfg.Locales)
+	}
+	if cfg.Sort != "" && cfg.Sort != config.SortAlpha && cfg.Sort != config.SortSource {
+		return fmt.Errorf("invalid sort mode %q: must be %q or %q", cfg.Sort, config.SortAlpha, config.SortSource)
+	}
+	if cfg.Backend != "" && cfg.Backend != config.BackendGoI18n && cfg.Backend != config.BackendFilesystem {
+		return fmt.Errorf("invalid backend %q: must be %q or %q", cfg.Backend, config.BackendGoI18n, config.BackendFilesystem)
+	}
+	if cfg.DataLayout != "" && cfg.DataLayout != config.DataLayoutInline &&
+		cfg.DataLayout != config.DataLayoutEmbedFile && cfg.DataLayout != config.DataLayoutExternal {
+		return fmt.Errorf("invalid data layout %q: must be %q, %q or %q",
+			cfg.DataLayout, config.DataLayoutInline, config.DataLayoutEmbedFile, config.DataLayoutExternal)
+	}
+	if cfg.InputFormat == "toml" {
+		return fmt.Errorf("invalid input format %q: TOML is supported for config files only; message and placeholder files must be %q or %q",
+			cfg.InputFormat, config.InputFormatYAML, config.InputFormatJSON)
+	}
+	if cfg.InputFormat != "" && cfg.InputFormat != config.InputFormatYAML && cfg.InputFormat != config.InputFormatJSON {
+		return fmt.Errorf("invalid input format %q: must be %q or %q", cfg.InputFormat, config.InputFormatYAML, config.InputFormatJSON)
+	}
+	if cfg.CountType != "" && cfg.CountType != config.CountTypeInt && cfg.CountType != config.CountTypeInt64 {
+		return fmt.Errorf("invalid count type %q: must be %q or %q", cfg.CountType, config.CountTypeInt, config.CountTypeInt64)
+	}
+	if cfg.ValueStyle != "" && cfg.ValueStyle != config.ValueStyleTyped && cfg.ValueStyle != config.ValueStylePlain {
+		return fmt.Errorf("invalid value style %q: must be %q or %q", cfg.ValueStyle, config.ValueStyleTyped, config.ValueStylePlain)
+	}
+	// The suffix completes an exported type name, and "Text" already names localized placeholder types
+	if suffix := cfg.GetValueSuffix(); (suffix != "" && !token.IsIdentifier("X"+suffix)) || suffix == "Text" {
+		return fmt.Errorf("invalid value suffix %q: must be empty or letters, digits and underscores other than %q", suffix, "Text")
+	}
+	if cfg.ParamsMinFields < 0 {
+		return fmt.Errorf("invalid params_constructor_min_fields %d: must be 0 (disabled) or a positive field count", cfg.ParamsMinFields)
+	}
+	if cfg.MaxParallel < 0 {
+		return fmt.Errorf("invalid max_parallel %d: must be 0 (number of CPUs) or a positive file count", cfg.MaxParallel)
+	}
+	if cfg.AutofillFrom != "" && !slices.Contains(cfg.Locales, cfg.AutofillFrom) {
+		return fmt.Errorf("autofill_from locale %q is not one of the configured locales %v", cfg.AutofillFrom, cfg.Locales)
+	}
+	if cfg.LocaleInfo {
+		for _, locale := range cfg.Locales {
+			if _, ok := cfg.LocaleNames[locale]; ok {
+				continue
+			}
+			if _, ok := utils.LookupLocaleName(locale); !ok {
+				return fmt.Errorf("locale %q has no built-in display information for emit_locale_info\n\nSuggestions:\n"+
+					"  - Add it under locale_info with name, native_name and rtl", locale)
+			}
+		}
+	}
+	if err := model.ValidateTemplateFunctions(cfg.TemplateFunctions); err != nil {
+		return err
+	}
+	if err := parser.ValidateSuffixSeparator(cfg.SuffixSeparator); err != nil {
+		return fmt.Errorf("%w\n\nSuggestions:\n  - Use punctuation such as %q or %q", err, ":", "__")
+	}
+	return nil
+}
+
+// Load parses message and placeholder files and builds the definitions used for generation
+func Load(cfg *config.Config) (*Corpus, error) {
+	// Validate input configuration
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+
+	if err := validateConfig(cfg); err != nil {
+		return nil, ConfigError(err)
+	}
+
+	// Determine primary locale (primary_locale, or the first locale in configuration)
+	primaryLocale := cfg.GetPrimaryLocale()
+
+	messages, err := parseMessages(cfg)
+	if err != nil {
+		return nil, InputError(err)
+	}
+
+	// Simple-format message files have no locale of their own; they provide the primary locale
+	messages, err = parser.ResolveDefaultLocale(messages, primaryLocale)
+	if err != nil {
+		return nil, InputError(fmt.Errorf(
+			"%w\n\nSuggestions:\n"+
+				"  - Remove the %q key and keep the primary-locale translation\n"+
+				"  - Rename the %q key to the locale it translates",
+			err, parser.DefaultLocale, parser.DefaultLocale))
+	}
+
+	if cfg.LocalesOnly {
+		messages = parser.KeepLocales(messages, cfg.Locales)
+	}
+
+	// With tags set, only the messages carrying one of them are generated
+	if len(cfg.Tags) > 0 {
+		messages = parser.FilterByTags(messages, cfg.Tags)
+		if len(messages) == 0 {
+			return nil, InputError(fmt.Errorf(
+				"no messages tagged %s found\n\nSuggestions:\n"+
+					"  - Add the tag to the tags list of the messages to generate\n"+
+					"  - Check the tags option or --tags for typos",
+				strings.Join(cfg.Tags, ", ")))
+		}
+	}
+
+	// A package holding one locale has no other locale to fall back to, so every message needs it
+	if cfg.LocalesOnly {
+		if missing := parser.FindMissingPrimaryLocale(messages, primaryLocale); len(missing) > 0 {
+			return nil, InputError(fmt.Errorf(
+				"messages without a template in locale %q, the only locale of the package in %q:\n  %s\n\nSuggestions:\n"+
+					"  - Translate the messages into %s\n"+
+					"  - Remove %s from output_dir to generate one package falling back to the primary locale",
+				primaryLocale, cfg.OutputDir, strings.Join(missing, "\n  "), primaryLocale, LocalePattern))
+		}
+	}
+
+	if err := parser.ValidateMessageLocales(messages, cfg.Locales); err != nil {
+		return nil, InputError(fmt.Errorf(
+			"%w\n\nSuggestions:\n"+
+				"  - Add a translation for one of the configured locales\n"+
+				"  - Add the locale to the locales list in the config file or pass --locales",
+			err))
+	}
+
+	if unconfigured := parser.FindUnconfiguredLocales(messages, cfg.Locales); len(unconfigured) > 0 {
+		if cfg.Strict {
+			return nil, InputError(fmt.Errorf(
+				"templates in unconfigured locales found:\n  %s\n\nSuggestions:\n"+
+					"  - Fix the locale key if it is a typo\n"+
+					"  - Add the locale to the locales list in the config file or pass --locales",
+				strings.Join(unconfigured, "\n  ")))
+		}
+		for _, entry := range unconfigured {
+			warnf(cfg, "template in unconfigured locale for %s", entry)
+		}
+	}
+
+	if empty := parser.FindEmptyTemplates(messages); len(empty) > 0 {
+		if cfg.Strict {
+			return nil, InputError(fmt.Errorf(
+				"empty templates found:\n  %s\n\nSuggestions:\n"+
+					"  - Translate the empty templates\n"+
+					"  - Remove the locale entry to fall back to the primary locale instead",
+				strings.Join(empty, "\n  ")))
+		}
+		for _, entry := range empty {
+			warnf(cfg, "empty template for %s", entry)
+		}
+	}
+
+	if missing := parser.FindMissingPluralForms(messages, cfg.NonPluralLocales); len(missing) > 0 {
+		if cfg.Strict {
+			return nil, InputError(fmt.Errorf(
+				"plural messages without plural forms in some locales found:\n  %s\n\nSuggestions:\n"+
+					"  - Translate the message with the plural forms of those locales (e.g. one and other)\n"+
+					"  - List locales that intentionally use one text for every count in non_plural_locales",
+				strings.Join(missing, "\n  ")))
+		}
+		for _, entry := range missing {
+			warnf(cfg, "plural message without plural forms for %s", entry)
+		}
+	}
+
+	// A missing count hint only slows translators down, so it is reported in strict mode without failing
+	if cfg.Strict {
+		for _, entry := range parser.FindMissingCountHints(messages) {
+			warnf(cfg, "plural message without count_hint for %s", entry)
+		}
+	}
+
+	placeholders, err := parser.ParsePlaceholdersFormat(cfg.PlaceholdersGlob, cfg.Locales, cfg.Compound, cfg.InputFormat)
+	if err != nil {
+		return nil, InputError(fmt.Errorf(
 			"failed to parse placeholder files from pattern %q:\n  %w\n\nSuggestions:\n"+
 				"  - Check that placeholder files have valid YAML syntax\n"+
 				"  - Verify placeholder names are valid Go identifiers\n"+
 				"  - Ensure all specified locales (%v) have corresponding values",
-			cfg.PlaceholdersGlob, err, cfg.Locales)
+			cfg.PlaceholdersGlob, err, cfg.Locales))
+	}
+
+	if cfg.LocalesOnly {
+		// Every locale package must declare the same types, so a kind cannot lose all its values in one
+		if missing := parser.FindPlaceholderKindsWithoutLocale(placeholders, primaryLocale); len(missing) > 0 {
+			return nil, InputError(fmt.Errorf(
+				"placeholder kinds without values in locale %q, the only locale of the package in %q:\n  %s\n\nSuggestions:\n"+
+					"  - Add the %s values of the items\n"+
+					"  - Remove %s from output_dir to generate one package falling back to the primary locale",
+				primaryLocale, cfg.OutputDir, strings.Join(missing, "\n  "), primaryLocale, LocalePattern))
+		}
+		placeholders = parser.KeepPlaceholderLocales(placeholders, cfg.Locales)
+	}
+
+	// Missing translations are bootstrapped from the autofill_from locale and flagged as untranslated
+	if cfg.AutofillFrom != "" {
+		filled := parser.AutofillMessages(messages, cfg.AutofillFrom, cfg.Locales)
+		filled = append(filled, parser.AutofillPlaceholders(placeholders, cfg.AutofillFrom, cfg.Locales)...)
+		for _, entry := range filled {
+			warnf(cfg, "untranslated %s: copied from %s", entry, cfg.AutofillFrom)
+		}
+	}
+
+	if err := parser.ValidatePlaceholderLocales(placeholders, cfg.Locales); err != nil {
+		return nil, InputError(fmt.Errorf(
+			"%w\n\nSuggestions:\n"+
+				"  - Add a name.<locale> placeholder file for every configured locale and fill in the item\n"+
+				"  - Set autofill_from to copy missing values from another locale",
+			err))
+	}
+
+	if cycles := parser.FindPlaceholderCycles(placeholders); len(cycles) > 0 {
+		if cfg.Strict {
+			return nil, InputError(fmt.Errorf(
+				"placeholder items referencing each other in a cycle found:\n  %s\n\nSuggestions:\n"+
+					"  - Remove one of the references, which would otherwise render as template syntax",
+				strings.Join(cycles, "\n  ")))
+		}
+		for _, entry := range cycles {
+			warnf(cfg, "placeholder reference cycle at %s", entry)
+		}
+	}
+
+	if found := parser.FindTemplateSyntax(placeholders); len(found) > 0 {
+		if cfg.Strict {
+			return nil, InputError(fmt.Errorf(
+				"placeholder text with template syntax found:\n  %s\n\nSuggestions:\n"+
+					"  - Reference another placeholder item as {{.item_id}}\n"+
+					"  - Move literal braces into the message text, escaped as {{\"{{\"}} and {{\"}}\"}}",
+				strings.Join(found, "\n  ")))
+		}
+		for _, entry := range found {
+			warnf(cfg, "template syntax in %s", entry)
+		}
 	}
 
 	// Validate that we have messages after parsing
 	if len(messages) == 0 {
-		return fmt.Errorf(
+		return nil, InputError(fmt.Errorf(
 			"no messages found after parsing pattern %q\n\nSuggestions:\n"+
 				"  - Check that message files exist in the specified location\n"+
 				"  - Verify the glob pattern is correct\n"+
 				"  - Ensure message files contain valid message definitions",
-			cfg.MessagesGlob)
+			cfg.MessagesGlob))
 	}
 
-	defs, err := model.Build(messages, placeholders, cfg.Locales, cfg)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to build models from parsed data:\n  %w\n\nSuggestions:\n"+
-				"  - Check for placeholder type mismatches\n"+
-				"  - Verify all message templates reference valid placeholders\n"+
-				"  - Ensure suffix notation is used correctly for multiple instances",
-			err)
+	if missing := parser.FindMissingPrimaryLocale(messages, primaryLocale); len(missing) > 0 {
+		if len(missing) == len(messages) {
+			return nil, InputError(fmt.Errorf(
+				"no message has a template in the primary locale %q\n\nSuggestions:\n"+
+					"  - Set primary_locale to the locale the messages are written in\n"+
+					"  - Reorder the locales list so that locale comes first",
+				primaryLocale))
+		}
+		if cfg.Strict {
+			return nil, InputError(fmt.Errorf(
+				"messages without a template in the primary locale %q found:\n  %s\n\nSuggestions:\n"+
+					"  - Add a %s translation to the messages\n"+
+					"  - Set autofill_from to copy missing translations from another locale",
+				primaryLocale, strings.Join(missing, "\n  "), primaryLocale))
+		}
+		for _, entry := range missing {
+			warnf(cfg, "no template in primary locale %s for %s", primaryLocale, entry)
+		}
 	}
 
-	if mkdirErr := os.MkdirAll(cfg.OutputDir, 0750); mkdirErr != nil {
-		return fmt.Errorf(
-			"failed to create output directory %q: %w\n\nSuggestions:\n"+
-				"  - Check directory permissions\n"+
-				"  - Ensure parent directories exist\n"+
-				"  - Verify the path is not read-only",
-			cfg.OutputDir, mkdirErr)
+	// {{entity "user"}} references become fixed fields; the corpus keeps the templates as written
+	resolved, err := model.ResolveItemRefs(messages, placeholders)
+	if err != nil {
+		return nil, InputError(fmt.Errorf(
+			"%w\n\nSuggestions:\n"+
+				"  - Reference an item ID defined in the placeholder file of the kind",
+			err))
 	}
 
-	// Determine primary locale (first locale in configuration)
-	primaryLocale := "en" // Default fallback
-	if len(cfg.Locales) > 0 {
-		primaryLocale = cfg.Locales[0]
+	defs, err := model.Build(resolved, placeholders, cfg.Locales, cfg)
+	if err != nil {
+		return nil, InputError(fmt.Errorf(
+			"failed to build models from parsed data:\n  %w\n\nSuggestions:\n"+
+				"  - Check for placeholder type mismatches\n"+
+				"  - Verify all message templates reference valid placeholders\n"+
+				"  - Ensure suffix notation is used correctly for multiple instances\n"+
+				"  - Make sure placeholder names of a message differ after CamelCasing (e.g. user_name and userName)\n"+
+				"  - Rename messages named like generated placeholder types (e.g. EntityText)",
+			err))
 	}
 
 	// Generate template data with enhanced error context
-	messageTemplates, placeholderTemplates, err := model.BuildTemplates(messages, placeholders, cfg.Locales)
+	messageTemplates, placeholderTemplates, err := model.BuildTemplates(resolved, placeholders, cfg.Locales)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, InputError(fmt.Errorf(
 			"failed to build templates:\n  %w\n\nSuggestions:\n"+
 				"  - Check for missing placeholder definitions\n"+
 				"  - Verify template syntax is valid\n"+
 				"  - Ensure all referenced placeholders exist",
-			err)
+			err))
 	}
 
-	// Generate i18n file
-	outputFile := filepath.Join(cfg.OutputDir, "i18n.gen.go")
+	return &Corpus{
+		Messages:             messages,
+		Placeholders:         placeholders,
+		Definitions:          defs,
+		MessageTemplates:     messageTemplates,
+		PlaceholderTemplates: placeholderTemplates,
+		PrimaryLocale:        primaryLocale,
+	}, nil
+}
 
-	// Generate go-i18n code
-	if err := templatex.RenderGoI18n(
-		outputFile,
-		cfg.OutputPackage,
-		primaryLocale,
-		messageTemplates,
-		placeholderTemplates,
-		defs.Placeholders,
-		defs.Messages,
-		cfg.Locales,
-	); err != nil {
-		return fmt.Errorf(
-			"failed to render go-i18n generated code to %q:\n  %w\n\nSuggestions:\n"+
-				"  - Check output directory permissions\n"+
-				"  - Verify package name is valid\n"+
-				"  - Ensure templates generate valid Go code\n"+
-				"  - Check for disk space availability",
-			outputFile, err)
+// checkMessageBackends rejects messages declaring backend: go-i18n when data_layout is external,
+// as no message data is embedded for them to be rendered from
+func checkMessageBackends(cfg *config.Config, defs *model.Definitions) error {
+	if cfg.DataLayout != config.DataLayoutExternal {
+		return nil
+	}
+	for _, msg := range defs.Messages {
+		if msg.Backend == config.BackendGoI18n {
+			return ConfigError(fmt.Errorf(
+				"message %q declares backend %q, but data_layout %q embeds no message data\n\nSuggestions:\n"+
+					"  - Remove the backend of the message\n"+
+					"  - Use data_layout %q or %q",
+				msg.ID, config.BackendGoI18n, config.DataLayoutExternal, config.DataLayoutInline, config.DataLayoutEmbedFile))
+		}
 	}
-
 	return nil
 }
+
+// embeddedMessages returns the sorted message IDs, including variants, rendered from the embedded
+// data only: those declaring backend: go-i18n, and those without a backend of their own unless the
+// backend option or data_layout loads messages at runtime. loader reports whether the filesystem
+// loader is generated at all; without it every message is embedded and none needs listing.
+func embeddedMessages(cfg *config.Config, messages []templatex.Message, loader bool) []string {
+	if !loader {
+		return nil
+	}
+	loadedByDefault := cfg.Backend == config.BackendFilesystem || cfg.DataLayout == config.DataLayoutExternal
+	var ids []string
+	for _, msg := range messages {
+		if msg.Backend == config.BackendFilesystem || (msg.Backend == "" && loadedByDefault) {
+			continue
+		}
+		ids = append(ids, msg.ID)
+		for _, variant := range msg.Variants {
+			ids = append(ids, variant.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// templateConfig derives the template rendering options from the configuration and the definitions
+func templateConfig(cfg *config.Config, buildConstraint string, defs *model.Definitions) *templatex.TemplateConfig {
+	// A message declaring backend: filesystem needs the loader even when the backend option is go-i18n
+	loader := cfg.Backend == config.BackendFilesystem || cfg.DataLayout == config.DataLayoutExternal
+	for _, msg := range defs.Messages {
+		loader = loader || msg.Backend == config.BackendFilesystem
+	}
+	return &templatex.TemplateConfig{
+		FilesystemLoader:    loader,
+		EmbeddedMessages:    embeddedMessages(cfg, defs.Messages, loader),
+		TranslationsDir:     cfg.TranslationsDir,
+		Localizer:           cfg.Localizer,
+		BuildConstraint:     buildConstraint,
+		RuntimePlaceholders: defs.RuntimePlaceholders,
+		Coverage:            cfg.Coverage,
+		LocalizeByID:        cfg.LocalizeByID,
+		ImplementError:      cfg.ImplementError,
+		EmbedData:           cfg.DataLayout == config.DataLayoutEmbedFile,
+		ExternalData:        cfg.DataLayout == config.DataLayoutExternal,
+		CustomFuncs:         cfg.TemplateFunctions,
+		CountType:           cfg.GetCountType(),
+		LocaleInfo:          localeInfo(cfg),
+	}
+}
+
+// localeInfo returns the display information of the configured locales when emit_locale_info is
+// set, taking each from locale_info in the configuration or else from the built-in table.
+// validateConfig has already rejected locales found in neither.
+func localeInfo(cfg *config.Config) []templatex.LocaleInfo {
+	if !cfg.LocaleInfo {
+		return nil
+	}
+	infos := make([]templatex.LocaleInfo, 0, len(cfg.Locales))
+	for _, locale := range cfg.Locales {
+		info := templatex.LocaleInfo{Locale: locale}
+		if name, ok := cfg.LocaleNames[locale]; ok {
+			info.Name, info.NativeName, info.RTL = name.Name, name.NativeName, name.RTL
+		} else if name, ok := utils.LookupLocaleName(locale); ok {
+			info.Name, info.NativeName, info.RTL = name.Name, name.NativeName, name.RTL
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// combineBuildTags combines build tags into a single //go:build expression, requiring all of them.
+// Each tag may itself be an expression such as "!debug"; an empty list yields an empty constraint.
+func combineBuildTags(tags []string) (string, error) {
+	var combined constraint.Expr
+	for _, tag := range tags {
+		expr, err := constraint.Parse("//go:build " + tag)
+		if err != nil {
+			return "", fmt.Errorf("invalid build tag %q: %w", tag, err)
+		}
+		if combined == nil {
+			combined = expr
+			continue
+		}
+		combined = &constraint.AndExpr{X: combined, Y: expr}
+	}
+	if combined == nil {
+		return "", nil
+	}
+	return combined.String(), nil
+}
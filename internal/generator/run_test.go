@@ -1,14 +1,22 @@
 package generator
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
 )
 
 func TestRun_Success(t *testing.T) {
@@ -78,6 +86,782 @@ product:
 	assert.Contains(t, contentStr, "NewEntityNotFound")
 }
 
+func TestRun_SimpleFormatMessages(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	// Simple format: message ID -> template without locale keys
+	messageContent := `Greeting: "Hello {{.name}}"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "testpkg",
+		Locales:          []string{"en", "ja"},
+		Compound:         true,
+	}
+	require.NoError(t, Run(cfg))
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "i18n.gen.go"))
+	require.NoError(t, err)
+
+	// The template is assigned to the primary locale rather than an orphaned "default" locale
+	contentStr := string(content)
+	assert.Contains(t, contentStr, "\"en\": []byte(`Greeting: \"Hello {{.name}}\"")
+	assert.NotContains(t, contentStr, `"default"`)
+}
+
+func TestRun_PrimaryLocale(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	messageContent := `Greeting: "こんにちは {{.name}}"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "testpkg",
+		Locales:          []string{"en", "ja"},
+		PrimaryLocale:    "ja",
+		Compound:         true,
+	}
+	require.NoError(t, Run(cfg))
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "i18n.gen.go"))
+	require.NoError(t, err)
+
+	// Simple-format templates and the bundle fallback use the primary locale, not the first one
+	contentStr := string(content)
+	assert.Contains(t, contentStr, "\"ja\": []byte(`Greeting: \"こんにちは {{.name}}\"")
+	assert.Contains(t, contentStr, `i18n.NewBundle(language.Make("ja"))`)
+}
+
+func TestRun_PrimaryLocaleNotConfigured(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		PrimaryLocale:    "fr",
+	}
+
+	err := Run(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `primary locale "fr" is not one of the configured locales`)
+}
+
+func TestRun_EmptyTemplates(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	messageContent := `Greeting:
+  ja: "こんにちは"
+  en: ""
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+			PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			Compound:         true,
+		}
+	}
+
+	t.Run("warns by default", func(t *testing.T) {
+		var warnings bytes.Buffer
+		cfg := newConfig()
+		cfg.Warnings = &warnings
+
+		require.NoError(t, Run(cfg))
+		assert.Contains(t, warnings.String(), `warning: empty template for message "Greeting" (locale: en)`)
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Strict = true
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty templates found")
+		assert.Contains(t, err.Error(), `message "Greeting" (locale: en)`)
+	})
+}
+
+func TestRun_UnconfiguredLocales(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	messageContent := `Greeting:
+  ja: "こんにちは"
+  en: "Hello"
+  jp: "こんにちは"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+			PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			Compound:         true,
+		}
+	}
+
+	t.Run("warns by default", func(t *testing.T) {
+		var warnings bytes.Buffer
+		cfg := newConfig()
+		cfg.Warnings = &warnings
+
+		require.NoError(t, Run(cfg))
+		assert.Contains(t, warnings.String(), `warning: template in unconfigured locale for message "Greeting" (locale: jp)`)
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Strict = true
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "templates in unconfigured locales found")
+		assert.Contains(t, err.Error(), `message "Greeting" (locale: jp)`)
+	})
+}
+
+func TestRun_MissingPluralForms(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	messageContent := `ItemCount:
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+  fr: "{{.Count}} articles"
+  ja: "{{.Count}}件"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+			PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"en", "fr", "ja"},
+			Compound:         true,
+		}
+	}
+
+	t.Run("warns by default", func(t *testing.T) {
+		var warnings bytes.Buffer
+		cfg := newConfig()
+		cfg.Warnings = &warnings
+
+		require.NoError(t, Run(cfg))
+		assert.Contains(t, warnings.String(),
+			`warning: plural message without plural forms for message "ItemCount" in `)
+		assert.Contains(t, warnings.String(), "(locales without plural forms: fr)")
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Strict = true
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "plural messages without plural forms in some locales found")
+		assert.Contains(t, err.Error(), "non_plural_locales")
+	})
+
+	t.Run("locales marked as non-plural are complete", func(t *testing.T) {
+		var warnings bytes.Buffer
+		cfg := newConfig()
+		cfg.Strict = true
+		cfg.Warnings = &warnings
+		cfg.NonPluralLocales = []string{"fr"}
+
+		require.NoError(t, Run(cfg))
+		assert.NotContains(t, warnings.String(), "plural forms")
+	})
+}
+
+func TestRun_CountHint(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`ItemCount:
+  count_hint: "usually 1-100"
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+FileCount:
+  en:
+    one: "{{.Count}} file"
+    other: "{{.Count}} files"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+		OutputDir:        filepath.Join(tempDir, "output"),
+		OutputPackage:    "testpkg",
+		Locales:          []string{"en"},
+		Compound:         true,
+	}
+
+	var warnings bytes.Buffer
+	cfg.Warnings = &warnings
+	require.NoError(t, Run(cfg))
+	assert.Empty(t, warnings.String(), "missing count hints are only reported in strict mode")
+
+	content, err := os.ReadFile(filepath.Join(cfg.OutputDir, OutputFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "// Expected count: usually 1-100\n")
+
+	cfg.Strict = true
+	require.NoError(t, Run(cfg))
+	assert.Contains(t, warnings.String(), `warning: plural message without count_hint for message "FileCount" in `)
+	assert.NotContains(t, warnings.String(), `"ItemCount"`)
+}
+
+// countingWarnings records warnings and counts them as a WarningCounter
+type countingWarnings struct {
+	bytes.Buffer
+	emitted int
+}
+
+func (w *countingWarnings) WarningEmitted() {
+	w.emitted++
+}
+
+func TestWarnf_CountsWarnings(t *testing.T) {
+	var warnings countingWarnings
+	cfg := &config.Config{Warnings: &warnings}
+
+	warnf(cfg, "templates found:\n  %s\n  %s", "first", "second")
+	warnf(cfg, "empty template for %s", "Hello")
+
+	assert.Equal(t, 2, warnings.emitted, "a warning spanning several lines is one warning")
+	assert.Equal(t, 4, strings.Count(warnings.String(), "\n"))
+}
+
+func TestRun_MissingPrimaryLocale(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	messageContent := `Hello:
+  ja: "こんにちは"
+  en: "Hello"
+Goodbye:
+  ja: "さようなら"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+			PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"en", "ja"},
+			Compound:         true,
+		}
+	}
+
+	t.Run("warns by default", func(t *testing.T) {
+		var warnings bytes.Buffer
+		cfg := newConfig()
+		cfg.Warnings = &warnings
+
+		require.NoError(t, Run(cfg))
+		assert.Contains(t, warnings.String(), `warning: no template in primary locale en for message "Goodbye" in `)
+		assert.NotContains(t, warnings.String(), `"Hello"`)
+	})
+
+	t.Run("fails in strict mode", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Strict = true
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `messages without a template in the primary locale "en" found`)
+		assert.Contains(t, err.Error(), "autofill_from")
+	})
+
+	t.Run("fails when no message has the primary locale", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Locales = []string{"fr", "ja", "en"}
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no message has a template in the primary locale "fr"`)
+		assert.Contains(t, err.Error(), "primary_locale")
+	})
+
+	t.Run("autofilled templates count", func(t *testing.T) {
+		var warnings bytes.Buffer
+		cfg := newConfig()
+		cfg.Strict = true
+		cfg.Warnings = &warnings
+		cfg.AutofillFrom = "ja"
+
+		require.NoError(t, Run(cfg))
+		assert.NotContains(t, warnings.String(), "primary locale")
+	})
+}
+
+func TestRun_Tags(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+
+	messageContent := `Welcome:
+  tags: [email, transactional]
+  ja: "ようこそ"
+  en: "Welcome"
+Banner:
+  tags: [web]
+  ja: "バナー"
+  en: "Banner"
+Hello:
+  ja: "こんにちは"
+  en: "Hello"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(messageContent), 0644))
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+			PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			Compound:         true,
+		}
+	}
+
+	t.Run("generates only tagged messages", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Tags = []string{"email"}
+
+		code, err := Generate(cfg)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "type Welcome struct")
+		assert.NotContains(t, string(code), "type Banner struct")
+		assert.NotContains(t, string(code), "type Hello struct")
+	})
+
+	t.Run("generates everything without tags", func(t *testing.T) {
+		code, err := Generate(newConfig())
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "type Banner struct")
+		assert.Contains(t, string(code), "type Hello struct")
+	})
+
+	t.Run("fails when no message has the tags", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Tags = []string{"push"}
+
+		_, err := Generate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no messages tagged push found")
+	})
+}
+
+func TestRun_AutofillFromNotConfigured(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		AutofillFrom:     "fr",
+	}
+
+	err := Run(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `autofill_from locale "fr" is not one of the configured locales`)
+}
+
+func TestRun_BuildTags(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`Greeting: "Hello {{.name}}"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "testpkg",
+		PackagePath:      "example.com/app/testpkg",
+		Locales:          []string{"en", "ja"},
+		BuildTags:        []string{"prod", "!debug"},
+		Compound:         true,
+	}
+	require.NoError(t, Run(cfg))
+
+	for _, path := range []string{
+		filepath.Join(outputDir, "i18n.gen.go"),
+		filepath.Join(outputDir, ExampleDir, "usage_example.go"),
+	} {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "DO NOT EDIT.\n\n//go:build prod && !debug\n\n", path)
+	}
+}
+
+func TestCombineBuildTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected string
+	}{
+		{name: "none", tags: nil, expected: ""},
+		{name: "single tag", tags: []string{"prod"}, expected: "prod"},
+		{name: "several tags", tags: []string{"prod", "linux"}, expected: "prod && linux"},
+		{name: "expressions", tags: []string{"prod || staging", "!debug"}, expected: "(prod || staging) && !debug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := combineBuildTags(tt.tags)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	for _, tag := range []string{"", "prod-build", "prod &&", "prod\npackage main"} {
+		t.Run("invalid "+tag, func(t *testing.T) {
+			_, err := combineBuildTags([]string{tag})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRun_InvalidBuildTags(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		BuildTags:        []string{"prod build"},
+	}
+
+	err := Run(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid build tag "prod build"`)
+	assert.Contains(t, err.Error(), "Suggestions")
+}
+
+func TestRun_Trace(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	placeholdersDir := filepath.Join(tempDir, "placeholders")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+
+	messageFile := filepath.Join(messagesDir, "errors.yaml")
+	require.NoError(t, os.WriteFile(messageFile, []byte(`EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`), 0644))
+	placeholderFile := filepath.Join(placeholdersDir, "entity.yaml")
+	require.NoError(t, os.WriteFile(placeholderFile, []byte(`# Entities
+user:
+  ja: "ユーザー"
+  en: "User"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(placeholdersDir, "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		Compound:         true,
+	}
+
+	// No trace file is written unless requested
+	require.NoError(t, Run(cfg))
+	assert.NoFileExists(t, filepath.Join(outputDir, TraceFileName))
+
+	cfg.Trace = true
+	require.NoError(t, Run(cfg))
+
+	data, err := os.ReadFile(filepath.Join(outputDir, TraceFileName))
+	require.NoError(t, err)
+
+	var trace Trace
+	require.NoError(t, json.Unmarshal(data, &trace))
+	assert.Equal(t, []TraceEntry{
+		{ID: "EntityNotFound", Symbol: "EntityNotFound", File: filepath.ToSlash(messageFile), Line: 1},
+	}, trace.Messages)
+	assert.Equal(t, []TraceEntry{
+		{ID: "user", Kind: "entity", Symbol: "EntityTexts.User", File: filepath.ToSlash(placeholderFile), Line: 2},
+	}, trace.Placeholders)
+}
+
+func TestGenerate_MatchesRun(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	placeholdersDir := filepath.Join(tempDir, "placeholders")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "errors.yaml"), []byte(`EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(placeholdersDir, "entity.yaml"), []byte(`user:
+  ja: "ユーザー"
+  en: "User"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(placeholdersDir, "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		Compound:         true,
+	}
+
+	code, err := Generate(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "package testpkg")
+	assert.NoDirExists(t, outputDir, "Generate does not touch the file system")
+
+	var buf bytes.Buffer
+	require.NoError(t, RunTo(cfg, &buf))
+	assert.Equal(t, code, buf.Bytes())
+
+	require.NoError(t, Run(cfg))
+	written, err := os.ReadFile(filepath.Join(outputDir, OutputFileName))
+	require.NoError(t, err)
+	assert.Equal(t, code, written)
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	placeholdersDir := filepath.Join(tempDir, "placeholders")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+
+	// Locales list placeholders in different orders, and use different casing for the count
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`Transfer:
+  ja: "{{.entity:to}}へ{{.entity:from}}から{{.amount}}を移動"
+  en: "Moved {{.amount}} from {{.entity:from}} to {{.entity:to}}"
+  fr: "{{.amount}} de {{.entity:from}} à {{.entity:to}}"
+  de: "{{.entity:from}} nach {{.entity:to}}: {{.amount}}"
+ItemsLeft:
+  ja: "残り{{.count}}個"
+  en:
+    one: "{{.Count}} item left"
+    other: "{{.Count}} items left"
+  fr:
+    one: "{{.Count}} article"
+    other: "{{.Count}} articles"
+  de: "{{.Count}} übrig"
+  variants:
+    short:
+      en: "{{.Count}} left"
+      de: "{{.Count}}"
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(placeholdersDir, "entity.yaml"), []byte(`user:
+  ja: "ユーザー"
+  en: "User"
+  fr: "Utilisateur"
+  de: "Benutzer"
+account:
+  ja: "アカウント"
+  en: "Account"
+  fr: "Compte"
+  de: "Konto"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(placeholdersDir, "*.yaml"),
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en", "fr", "de"},
+		Compound:         true,
+		EnumPlaceholders: map[string][]string{"status": {"pending", "done"}},
+	}
+
+	first, err := Generate(cfg)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		code, err := Generate(cfg)
+		require.NoError(t, err)
+		require.Equal(t, string(first), string(code), "generation %d differs from the first one", i+2)
+	}
+}
+
+func TestRun_IfStale(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	placeholdersDir := filepath.Join(tempDir, "placeholders")
+	outputDir := filepath.Join(tempDir, "output")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+
+	messageFile := filepath.Join(messagesDir, "errors.yaml")
+	require.NoError(t, os.WriteFile(messageFile, []byte(`EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`), 0644))
+	placeholderFile := filepath.Join(placeholdersDir, "entity.yaml")
+	require.NoError(t, os.WriteFile(placeholderFile, []byte(`user:
+  ja: "ユーザー"
+  en: "User"
+`), 0644))
+	configFile := filepath.Join(tempDir, "i18ngen.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("compound: true\n"), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(placeholdersDir, "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		Compound:         true,
+		IfStale:          true,
+		ConfigPath:       configFile,
+	}
+	outputFile := filepath.Join(outputDir, OutputFileName)
+
+	// A missing output is always stale
+	require.NoError(t, Run(cfg))
+	require.FileExists(t, outputFile)
+
+	past := time.Now().Add(-time.Hour)
+	for _, path := range []string{messageFile, placeholderFile, messagesDir, placeholdersDir, configFile} {
+		require.NoError(t, os.Chtimes(path, past, past))
+	}
+	// markOutput appends a marker to the generated code, keeping the digest in its header
+	marker := "// not regenerated\n"
+	markOutput := func() {
+		content, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(outputFile, append(content, marker...), 0644))
+	}
+	markOutput()
+
+	require.NoError(t, Run(cfg))
+	content, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(content), marker), "generation is skipped when every input is older than the output")
+
+	for _, path := range []string{configFile, messageFile} {
+		future := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(path, future, future))
+		require.NoError(t, Run(cfg))
+		content, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.False(t, strings.HasSuffix(string(content), marker), "a newer %s triggers generation", filepath.Base(path))
+
+		require.NoError(t, os.Chtimes(path, past, past))
+		markOutput()
+	}
+
+	// A flag changing the output makes it stale although no input changed
+	renamed := *cfg
+	renamed.OutputPackage = "renamed"
+	require.NoError(t, Run(&renamed))
+	content, err = os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "package renamed")
+
+	// Requested outputs that were never written make the output stale
+	cfg.Trace = true
+	require.NoError(t, Run(cfg))
+	assert.FileExists(t, filepath.Join(outputDir, TraceFileName))
+}
+
+func TestRun_UnconfiguredLocaleOnly(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`Hello:
+  en: "Hello"
+Foo:
+  de: "Foo {{.name}}"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+		OutputDir:        filepath.Join(tempDir, "output"),
+		OutputPackage:    "testpkg",
+		Locales:          []string{"en", "ja"},
+		Compound:         true,
+	}
+
+	err := Run(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `message "Foo"`)
+	assert.Contains(t, err.Error(), "found: [de]")
+}
+
+func TestRun_SimplePlaceholderMissingLocale(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	placeholdersDir := filepath.Join(tempDir, "placeholders")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "messages.yaml"), []byte(`Required:
+  en: "{{.field}} is required"
+  ja: "{{.field}}は必須です"
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(placeholdersDir, "field.en.yaml"), []byte("FirstName: \"First Name\"\nLastName: \"Last Name\"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(placeholdersDir, "field.ja.yaml"), []byte("FirstName: \"名前\"\n"), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(placeholdersDir, "*.yaml"),
+		OutputDir:        filepath.Join(tempDir, "output"),
+		OutputPackage:    "testpkg",
+		Locales:          []string{"en", "ja"},
+	}
+
+	err := Run(cfg)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	assert.Contains(t, err.Error(), `placeholder "field" item "LastName"`)
+	assert.Contains(t, err.Error(), `has no value for locale "ja": add it to field.ja.yaml`)
+
+	// Values bootstrapped by autofill_from count as present
+	cfg.AutofillFrom = "en"
+	require.NoError(t, Run(cfg))
+}
+
 func TestRun_InvalidMessagesGlob(t *testing.T) {
 	cfg := &config.Config{
 		MessagesGlob:     "[invalid-glob",
@@ -244,6 +1028,322 @@ func TestRun_EmptyLocales(t *testing.T) {
 	assert.Contains(t, err.Error(), "no locales specified")
 }
 
+func TestRun_InvalidSortMode(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		Sort:             "random",
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid sort mode")
+}
+
+func TestRun_InvalidMaxParallel(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		MaxParallel:      -1,
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid max_parallel")
+}
+
+func TestRun_InvalidBackend(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		Backend:          "database",
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid backend")
+}
+
+func TestRun_InvalidValueStyle(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		ValueStyle:       "raw",
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid value style")
+}
+
+func TestRun_InvalidSuffixSeparator(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		SuffixSeparator:  "_",
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid suffix separator")
+}
+
+func TestRun_InvalidPackageName(t *testing.T) {
+	for _, pkg := range []string{"my-pkg", "123", "type", ""} {
+		t.Run(pkg, func(t *testing.T) {
+			cfg := &config.Config{
+				MessagesGlob:     "./messages/*.yaml",
+				PlaceholdersGlob: "./placeholders/*.yaml",
+				OutputDir:        "./output",
+				OutputPackage:    pkg,
+				Locales:          []string{"ja", "en"},
+			}
+
+			err := Run(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "package name")
+			assert.Contains(t, err.Error(), "Suggestions")
+		})
+	}
+}
+
+func TestGenerate_DataFiles(t *testing.T) {
+	for _, layout := range []string{config.DataLayoutEmbedFile, config.DataLayoutExternal} {
+		cfg := &config.Config{
+			MessagesGlob:     "./messages/*.yaml",
+			PlaceholdersGlob: "./placeholders/*.yaml",
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			DataLayout:       layout,
+		}
+
+		_, err := Generate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "writes data files next to the generated code")
+	}
+}
+
+func TestRun_InvalidDataLayout(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		DataLayout:       "remote",
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid data layout")
+}
+
+func TestRun_LocaleOutputDir(t *testing.T) {
+	tempDir := t.TempDir()
+	messagesDir := filepath.Join(tempDir, "messages")
+	require.NoError(t, os.MkdirAll(messagesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "greetings.yaml"), []byte(`Hello:
+  ja: "こんにちは"
+  en: "Hello"
+`), 0644))
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+		OutputDir:        filepath.Join(tempDir, "i18n", "{locale}"),
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		PrimaryLocale:    "en",
+		Compound:         true,
+	}
+
+	require.NoError(t, Run(cfg))
+	for locale, text := range map[string]struct{ own, other string }{
+		"ja": {own: `Hello: "こんにちは"`, other: `Hello: "Hello"`},
+		"en": {own: `Hello: "Hello"`, other: "こんにちは"},
+	} {
+		content, err := os.ReadFile(filepath.Join(tempDir, "i18n", locale, OutputFileName))
+		require.NoError(t, err, locale)
+		assert.Contains(t, string(content), "package testpkg", locale)
+		assert.Contains(t, string(content), text.own, locale)
+		assert.NotContains(t, string(content), text.other, locale)
+		assert.Contains(t, string(content), fmt.Sprintf("language.Make(%q)", locale), "each package falls back to its own locale")
+	}
+	assert.NoDirExists(t, filepath.Join(tempDir, "i18n", "{locale}"))
+
+	t.Run("autofill_from is rejected", func(t *testing.T) {
+		autofill := *cfg
+		autofill.AutofillFrom = "en"
+		err := Run(&autofill)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Contains(t, err.Error(), "autofill_from cannot copy texts into a package holding one locale")
+	})
+
+	t.Run("placeholder kind without values in a locale is rejected", func(t *testing.T) {
+		placeholdersDir := filepath.Join(tempDir, "placeholders")
+		require.NoError(t, os.MkdirAll(placeholdersDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(placeholdersDir, "entity.yaml"), []byte(`user:
+  ja: "ユーザー"
+`), 0644))
+		defer func() { require.NoError(t, os.RemoveAll(placeholdersDir)) }()
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.Contains(t, err.Error(), `placeholder kinds without values in locale "en"`)
+		assert.Contains(t, err.Error(), filepath.Join(tempDir, "i18n", "en"))
+		assert.Contains(t, err.Error(), `placeholder "entity"`)
+	})
+
+	t.Run("message without a template in a locale is rejected", func(t *testing.T) {
+		missing := filepath.Join(messagesDir, "farewells.yaml")
+		require.NoError(t, os.WriteFile(missing, []byte(`Bye:
+  ja: "さようなら"
+`), 0644))
+		defer func() { require.NoError(t, os.Remove(missing)) }()
+
+		err := Run(cfg)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.Contains(t, err.Error(), `messages without a template in locale "en"`)
+		assert.Contains(t, err.Error(), filepath.Join(tempDir, "i18n", "en"))
+		assert.Contains(t, err.Error(), `message "Bye"`)
+	})
+}
+
+func TestOutputDirs(t *testing.T) {
+	assert.Equal(t, []string{"i18n"}, OutputDirs("i18n", []string{"ja", "en"}))
+	assert.Equal(t, []string{"i18n/ja/gen", "i18n/en/gen"}, OutputDirs("i18n/{locale}/gen", []string{"ja", "en"}))
+}
+
+func TestRun_InvalidValueSuffix(t *testing.T) {
+	for _, suffix := range []string{"-arg", "Text"} {
+		cfg := &config.Config{
+			MessagesGlob:     "./messages/*.yaml",
+			PlaceholdersGlob: "./placeholders/*.yaml",
+			OutputDir:        "./output",
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			ValueSuffix:      &suffix,
+		}
+
+		err := Run(cfg)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value suffix")
+	}
+}
+
+func TestRun_InvalidCountType(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		CountType:        "uint",
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid count type")
+}
+
+func TestRun_UnknownLocaleInfo(t *testing.T) {
+	cfg := &config.Config{
+		MessagesGlob:     "./messages/*.yaml",
+		PlaceholdersGlob: "./placeholders/*.yaml",
+		OutputDir:        "./output",
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "tlh"},
+		LocaleInfo:       true,
+	}
+
+	err := Run(cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `locale "tlh" has no built-in display information`)
+
+	// An entry under locale_info covers a locale missing from the built-in table
+	cfg.LocaleNames = map[string]config.LocaleName{"tlh": {Name: "Klingon", NativeName: "tlhIngan Hol"}}
+	assert.NoError(t, validateConfig(cfg))
+}
+
+func TestRun_InputFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	inputDir := filepath.Join(tempDir, "input")
+	require.NoError(t, os.MkdirAll(inputDir, 0755))
+
+	newConfig := func(messages, placeholders string) *config.Config {
+		return &config.Config{
+			MessagesGlob:     filepath.Join(inputDir, messages),
+			PlaceholdersGlob: filepath.Join(inputDir, placeholders),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			Compound:         true,
+		}
+	}
+
+	t.Run("extensionless files forced to YAML", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(inputDir, "messages"), []byte(`EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(inputDir, "entity"), []byte(`user:
+  ja: "ユーザー"
+  en: "User"
+`), 0644))
+
+		cfg := newConfig("messages", "entity")
+		cfg.InputFormat = config.InputFormatYAML
+		code, err := Generate(cfg)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "type EntityNotFound struct")
+		assert.Contains(t, string(code), "Entity EntityText")
+	})
+
+	t.Run("nonstandard extension forced to JSON", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(inputDir, "messages.i18n"), []byte(
+			`{"Hello": {"ja": "こんにちは", "en": "Hello"}}`), 0644))
+
+		cfg := newConfig("messages.i18n", "*.missing")
+		cfg.InputFormat = config.InputFormatJSON
+		code, err := Generate(cfg)
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "type Hello struct")
+	})
+
+	t.Run("TOML is rejected", func(t *testing.T) {
+		cfg := newConfig("messages", "entity")
+		cfg.InputFormat = "toml"
+		_, err := Generate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TOML is supported for config files only")
+	})
+
+	t.Run("unknown formats are rejected", func(t *testing.T) {
+		cfg := newConfig("messages", "entity")
+		cfg.InputFormat = "xml"
+		_, err := Generate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid input format "xml"`)
+	})
+}
+
 func TestRun_NilConfig(t *testing.T) {
 	err := Run(nil)
 	assert.Error(t, err)
@@ -266,3 +1366,187 @@ func containsAt(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCheckFieldTypes(t *testing.T) {
+	defs := &model.Definitions{
+		Placeholders: []templatex.Placeholder{{StructName: "EntityText"}},
+		Messages: []templatex.Message{{
+			ID: "EntityNotFound",
+			Fields: []templatex.Field{
+				{FieldName: "Entity", Type: "EntityText"},
+				{FieldName: "Note", Type: "string", PlainValue: true},
+			},
+		}},
+	}
+	require.NoError(t, checkFieldTypes(defs))
+
+	defs.Messages[0].Fields = append(defs.Messages[0].Fields, templatex.Field{FieldName: "UserId", Type: "UserIdValue"})
+	err := checkFieldTypes(defs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `internal error: field UserId of message "EntityNotFound" has type UserIdValue, which is not generated`)
+}
+
+func TestRun_MessageBackendWithExternalData(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "messages.yaml"), []byte(`Hello:
+  backend: go-i18n
+  ja: "こんにちは"
+  en: "Hello"
+`), 0644))
+
+	cfg := &config.Config{
+		MessagesGlob:     filepath.Join(tempDir, "messages.yaml"),
+		PlaceholdersGlob: filepath.Join(tempDir, "*.missing"),
+		OutputDir:        filepath.Join(tempDir, "output"),
+		OutputPackage:    "testpkg",
+		Locales:          []string{"ja", "en"},
+		Compound:         true,
+		DataLayout:       config.DataLayoutExternal,
+	}
+
+	err := Run(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `message "Hello" declares backend "go-i18n", but data_layout "external" embeds no message data`)
+}
+
+func TestInputsDigest(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a", "b"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "messages", dir), 0755))
+	}
+	message := filepath.Join(tempDir, "messages", "a", "errors.yaml")
+	require.NoError(t, os.WriteFile(message, []byte("Hello:\n  en: Hello\n"), 0644))
+	cfg := &config.Config{
+		MessagesGlob:  filepath.Join(tempDir, "messages", "*", "*.yaml"),
+		OutputDir:     filepath.Join(tempDir, "output"),
+		OutputPackage: "testpkg",
+		Locales:       []string{"en"},
+	}
+
+	digest, err := inputsDigest(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(message, filepath.Join(tempDir, "messages", "b", "errors.yaml")))
+	moved, err := inputsDigest(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, moved, "moving a file to another directory changes the digest")
+
+	cfg.Locales = []string{"en", "ja"}
+	relocalized, err := inputsDigest(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, moved, relocalized, "the effective configuration is part of the digest")
+}
+
+func TestRun_SinceGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	runGit := func(t *testing.T, dir string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	newRepo := func(t *testing.T, commit bool) (*config.Config, string) {
+		tempDir := t.TempDir()
+		messagesDir := filepath.Join(tempDir, "messages")
+		require.NoError(t, os.MkdirAll(messagesDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(messagesDir, "errors.yaml"), []byte(`Hello:
+  ja: "こんにちは"
+  en: "Hello"
+`), 0644))
+		if commit {
+			runGit(t, tempDir, "init", "-q")
+			runGit(t, tempDir, "add", ".")
+			runGit(t, tempDir, "commit", "-q", "-m", "messages")
+		}
+		return &config.Config{
+			MessagesGlob:     filepath.Join(messagesDir, "*.yaml"),
+			PlaceholdersGlob: filepath.Join(tempDir, "placeholders", "*.yaml"),
+			OutputDir:        filepath.Join(tempDir, "output"),
+			OutputPackage:    "testpkg",
+			Locales:          []string{"ja", "en"},
+			Compound:         true,
+			SinceGit:         true,
+		}, filepath.Join(tempDir, "output", OutputFileName)
+	}
+	marker := "// not regenerated\n"
+	// markOutput appends marker to the generated code, keeping its header
+	markOutput := func(t *testing.T, outputFile string) {
+		content, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(outputFile, append(content, marker...), 0644))
+	}
+
+	t.Run("unchanged inputs skip generation", func(t *testing.T) {
+		cfg, outputFile := newRepo(t, true)
+
+		// A missing output is always generated
+		require.NoError(t, Run(cfg))
+		content, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "DO NOT EDIT.\n// i18ngen inputs: sha256:")
+
+		markOutput(t, outputFile)
+		require.NoError(t, Run(cfg))
+		content, err = os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(content), marker))
+
+		// A flag changing the output counts as a change even though git shows none
+		renamed := *cfg
+		renamed.OutputPackage = "renamed"
+		require.NoError(t, Run(&renamed))
+		content, err = os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "package renamed")
+		require.NoError(t, Run(cfg))
+
+		// An untracked message file counts as a change
+		require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(cfg.MessagesGlob), "more.yaml"), []byte(`Bye:
+  ja: "さようなら"
+  en: "Bye"
+`), 0644))
+		require.NoError(t, Run(cfg))
+		content, err = os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "type Bye struct")
+	})
+
+	t.Run("output generated from other inputs is regenerated", func(t *testing.T) {
+		cfg, outputFile := newRepo(t, true)
+		require.NoError(t, Run(cfg))
+
+		// Committing a change leaves a clean working tree, but the output predates it
+		require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(cfg.MessagesGlob), "errors.yaml"), []byte(`Hello:
+  ja: "やあ"
+  en: "Hi"
+`), 0644))
+		runGit(t, filepath.Dir(filepath.Dir(cfg.MessagesGlob)), "commit", "-q", "-am", "reword")
+		require.NoError(t, Run(cfg))
+		content, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Hi")
+
+		// An output without a recorded digest is regenerated too
+		noDigest := *cfg
+		noDigest.SinceGit = false
+		require.NoError(t, Run(&noDigest))
+		markOutput(t, outputFile)
+		require.NoError(t, Run(cfg))
+		content, err = os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.False(t, strings.HasSuffix(string(content), marker))
+	})
+
+	t.Run("outside a repository inputs are always regenerated", func(t *testing.T) {
+		cfg, outputFile := newRepo(t, false)
+		require.NoError(t, os.MkdirAll(filepath.Dir(outputFile), 0755))
+		require.NoError(t, os.WriteFile(outputFile, []byte(marker), 0644))
+
+		require.NoError(t, Run(cfg))
+		content, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "package testpkg")
+	})
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+// inputsUnchangedInGit reports whether git shows no change to any input in the working tree:
+// no message or placeholder file matching the globs, nor the config file, is modified, added,
+// deleted or untracked relative to HEAD, and every output exists. It reports false, so the
+// output is fully regenerated, when the inputs are not in a git repository or git fails.
+// A clean tree does not prove the output is current, so Run also checks outputMatchesInputs.
+func inputsUnchangedInGit(cfg *config.Config) bool {
+	if _, ok, err := oldestModTime(outputPaths(cfg)); err != nil || !ok {
+		return false
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(cfg.MessagesGlob))
+	if err != nil {
+		return false
+	}
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return false
+	}
+	root = strings.TrimSpace(root)
+	changed, err := git(root, "diff", "--name-only", "HEAD")
+	if err != nil {
+		return false
+	}
+	untracked, err := git(root, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return false
+	}
+
+	patterns := make([]string, 0, 3)
+	for _, pattern := range []string{cfg.MessagesGlob, cfg.PlaceholdersGlob, cfg.ConfigPath} {
+		if pattern == "" {
+			continue
+		}
+		abs, err := filepath.Abs(pattern)
+		if err != nil {
+			return false
+		}
+		// git reports paths below the resolved root, so resolve symlinks in the directory too
+		if resolved, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+			abs = filepath.Join(resolved, filepath.Base(abs))
+		}
+		patterns = append(patterns, abs)
+	}
+	for _, name := range strings.Split(changed+untracked, "\n") {
+		if name == "" {
+			continue
+		}
+		path := filepath.Join(root, filepath.FromSlash(name))
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(pattern, path); matched {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// git runs git with args in dir and returns its standard output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...) // #nosec G204 - Arguments are fixed by the caller
+	cmd.Dir = dir
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return stdout.String(), nil
+}
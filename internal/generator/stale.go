@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+)
+
+// outputsUpToDate reports whether every file Run would write exists and is newer than all
+// inputs: the config file, the files matching the message and placeholder globs, and the
+// directories holding them, whose mtime changes when an input file is added or removed.
+// The generated code must also record the digest of the inputs and the effective configuration,
+// since flags such as --package change the output without touching any file.
+func outputsUpToDate(cfg *config.Config) (bool, error) {
+	oldestOutput, ok, err := oldestModTime(outputPaths(cfg))
+	if err != nil || !ok {
+		return false, err
+	}
+
+	inputs, err := inputPaths(cfg)
+	if err != nil {
+		// Let Load report invalid patterns with its own diagnostics
+		return false, nil
+	}
+	for _, path := range inputs {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to stat input %q: %w", path, err)
+		}
+		if !info.ModTime().Before(oldestOutput) {
+			return false, nil
+		}
+	}
+	return outputMatchesInputs(cfg), nil
+}
+
+// outputPaths lists the files Run writes for cfg
+func outputPaths(cfg *config.Config) []string {
+	paths := []string{filepath.Join(cfg.OutputDir, OutputFileName)}
+	if cfg.PackagePath != "" {
+		paths = append(paths, filepath.Join(cfg.OutputDir, ExampleDir, templatex.UsageExampleFileName))
+	}
+	if cfg.Trace {
+		paths = append(paths, filepath.Join(cfg.OutputDir, TraceFileName))
+	}
+	if cfg.OutputTest {
+		paths = append(paths, filepath.Join(cfg.OutputDir, templatex.SmokeTestFileName))
+	}
+	if cfg.DataFiles() {
+		for _, locale := range cfg.Locales {
+			paths = append(paths, filepath.Join(cfg.OutputDir, templatex.MessageDataFileName(locale)))
+		}
+	}
+	if cfg.DataLayout == config.DataLayoutEmbedFile {
+		paths = append(paths, filepath.Join(cfg.OutputDir, templatex.PlaceholderDataFileName))
+	}
+	return paths
+}
+
+// inputPaths lists the config file and the message and placeholder files of cfg together with
+// their directories
+func inputPaths(cfg *config.Config) ([]string, error) {
+	var paths []string
+	if cfg.ConfigPath != "" {
+		paths = append(paths, cfg.ConfigPath)
+	}
+	dirs := make(map[string]bool)
+	for _, pattern := range []string{cfg.MessagesGlob, cfg.PlaceholdersGlob} {
+		if pattern == "" {
+			continue
+		}
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			paths = append(paths, file)
+			if dir := filepath.Dir(file); !dirs[dir] {
+				dirs[dir] = true
+				paths = append(paths, dir)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// oldestModTime returns the earliest modification time of paths; ok is false when any is missing
+func oldestModTime(paths []string) (oldest time.Time, ok bool, err error) {
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return time.Time{}, false, nil
+		}
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("failed to stat output %q: %w", path, err)
+		}
+		if i == 0 || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+		}
+	}
+	return oldest, true, nil
+}
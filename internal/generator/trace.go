@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+// TraceFileName is the name of the sidecar file written next to the generated code in trace mode
+const TraceFileName = "i18n.gen.trace.json"
+
+// Trace maps generated symbols to the source files that introduced them
+type Trace struct {
+	Messages     []TraceEntry `json:"messages"`
+	Placeholders []TraceEntry `json:"placeholders"`
+}
+
+// TraceEntry records the provenance of a single generated symbol
+type TraceEntry struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind,omitempty"`
+	Symbol string `json:"symbol"`
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+}
+
+// BuildTrace collects the source location of every generated message and placeholder item,
+// in the same order as the generated code
+func BuildTrace(corpus *Corpus) *Trace {
+	trace := &Trace{
+		Messages:     []TraceEntry{},
+		Placeholders: []TraceEntry{},
+	}
+
+	messageLocations := make(map[string]model.SourceLocation, len(corpus.Messages))
+	for _, msg := range corpus.Messages {
+		if _, exists := messageLocations[msg.ID]; !exists {
+			messageLocations[msg.ID] = msg.Location
+		}
+	}
+	for _, msg := range corpus.Definitions.Messages {
+		location := messageLocations[msg.ID]
+		trace.Messages = append(trace.Messages, TraceEntry{
+			ID:     msg.ID,
+			Symbol: msg.StructName,
+			File:   filepath.ToSlash(location.File),
+			Line:   location.Line,
+		})
+	}
+
+	placeholderLocations := make(map[string]map[string]model.SourceLocation, len(corpus.Placeholders))
+	for _, ph := range corpus.Placeholders {
+		placeholderLocations[ph.Kind] = ph.ItemLocations
+	}
+	for _, ph := range corpus.Definitions.Placeholders {
+		// Value placeholders are inferred from message templates and enums come from the config,
+		// so neither has a source file
+		if ph.IsValue || ph.IsEnum {
+			continue
+		}
+		for _, item := range ph.Items {
+			location := placeholderLocations[ph.Kind][item.ID]
+			trace.Placeholders = append(trace.Placeholders, TraceEntry{
+				ID:     item.ID,
+				Kind:   ph.Kind,
+				Symbol: fmt.Sprintf("%ss.%s", ph.StructName, item.FieldName),
+				File:   filepath.ToSlash(location.File),
+				Line:   location.Line,
+			})
+		}
+	}
+
+	return trace
+}
+
+// WriteTrace writes the trace of the corpus as indented JSON
+func WriteTrace(path string, corpus *Corpus) error {
+	data, err := json.MarshalIndent(BuildTrace(corpus), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode trace: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write trace file %q: %w", path, err)
+	}
+	return nil
+}
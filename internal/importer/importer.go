@@ -0,0 +1,220 @@
+// Package importer merges translations edited in external file formats back into message files.
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/exporter"
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+	"github.com/hacomono-lib/go-i18ngen/internal/utils"
+	"github.com/hacomono-lib/go-i18ngen/internal/yamledit"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatCSV imports the translation matrix produced by `export --format csv`
+	FormatCSV = exporter.FormatCSV
+)
+
+// Formats lists the supported import formats
+var Formats = []string{FormatCSV}
+
+// ImportCSV merges the translations of a CSV matrix into the YAML files defining the messages
+// and returns the paths of the files that changed.
+//
+// Only non-empty cells are applied, so untranslated cells never remove existing text.
+// Rows with a form column set update that plural form; other rows update plain templates.
+// Comments and the layout of untouched entries are preserved.
+func ImportCSV(r io.Reader, messages []model.MessageSource, locales []string) ([]string, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columnLocales, err := headerLocales(header, locales)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]model.MessageSource, len(messages))
+	for _, msg := range messages {
+		if _, exists := sources[msg.ID]; !exists {
+			sources[msg.ID] = msg
+		}
+	}
+
+	offset := len(exporter.CSVHeader(nil))
+	files := map[string]*messageFile{}
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		id, form := row[0], row[1]
+		source, ok := sources[id]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown message %q", line, id)
+		}
+		if form != "" && !utils.IsPluralCategory(form) {
+			return nil, fmt.Errorf("line %d: invalid plural form %q for message %q: must be one of %v",
+				line, form, id, utils.PluralCategories)
+		}
+
+		file, err := loadMessageFile(files, source.Location.File)
+		if err != nil {
+			return nil, err
+		}
+		for i, locale := range columnLocales {
+			text := row[offset+i]
+			if text == "" {
+				continue
+			}
+			if err := file.set(id, locale, form, text); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	}
+
+	var written []string
+	for path, file := range files {
+		if !file.changed {
+			continue
+		}
+		changed, err := file.Write()
+		if err != nil {
+			return nil, fmt.Errorf("failed to write message file: %w", err)
+		}
+		if changed {
+			written = append(written, path)
+		}
+	}
+	sort.Strings(written)
+	return written, nil
+}
+
+// headerLocales validates the CSV header and returns the locale of each translation column
+func headerLocales(header []string, locales []string) ([]string, error) {
+	fixed := exporter.CSVHeader(nil)
+	if len(header) < len(fixed) || !slices.Equal(header[:len(fixed)], fixed) {
+		return nil, fmt.Errorf("invalid CSV header %v: must start with %v followed by locale columns", header, fixed)
+	}
+
+	columnLocales := header[len(fixed):]
+	for _, locale := range columnLocales {
+		if !slices.Contains(locales, locale) {
+			return nil, fmt.Errorf("CSV column %q is not a configured locale %v", locale, locales)
+		}
+	}
+	return columnLocales, nil
+}
+
+// messageFile is a YAML message file being edited
+type messageFile struct {
+	*yamledit.File
+	changed bool
+}
+
+func loadMessageFile(files map[string]*messageFile, path string) (*messageFile, error) {
+	if file, ok := files[path]; ok {
+		return file, nil
+	}
+	if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+		return nil, fmt.Errorf("cannot import into %q: only YAML message files are supported", path)
+	}
+
+	edited, err := yamledit.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load message file: %w", err)
+	}
+	if root := edited.Root(); root == nil || root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("message file %q must be a mapping of message IDs", path)
+	}
+	file := &messageFile{File: edited}
+	files[path] = file
+	return file, nil
+}
+
+func (f *messageFile) set(id, locale, form, text string) error {
+	message := mappingValue(f.Root(), id)
+	if message == nil {
+		return fmt.Errorf("message %q not found in %q", id, f.Path)
+	}
+	if message.Kind != yaml.MappingNode {
+		return fmt.Errorf("message %q in %q uses the simple format: only compound message files can be imported", id, f.Path)
+	}
+
+	value := mappingValue(message, locale)
+	if form == "" {
+		if value != nil && value.Kind == yaml.MappingNode {
+			return fmt.Errorf("message %q has plural forms for locale %q: use one row per plural form", id, locale)
+		}
+		if value == nil {
+			value = appendMapping(message, locale, &yaml.Node{Kind: yaml.ScalarNode})
+		}
+		f.setScalar(value, text)
+		return nil
+	}
+
+	switch {
+	case value == nil:
+		value = appendMapping(message, locale, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+	case value.Kind != yaml.MappingNode:
+		// Plain templates are exported on the "other" row of plural messages
+		if form == "other" {
+			f.setScalar(value, text)
+			return nil
+		}
+		// Any other form turns the plain template into plural forms, keeping it as "other"
+		other := *value
+		*value = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		appendMapping(value, "other", &other)
+		f.changed = true
+	}
+	formValue := mappingValue(value, form)
+	if formValue == nil {
+		formValue = appendMapping(value, form, &yaml.Node{Kind: yaml.ScalarNode})
+	}
+	f.setScalar(formValue, text)
+	return nil
+}
+
+func (f *messageFile) setScalar(node *yaml.Node, text string) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && node.Value == text {
+		return
+	}
+	if node.Kind != yaml.ScalarNode || node.Value == "" {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	node.Kind = yaml.ScalarNode
+	node.Tag = "!!str"
+	node.Value = text
+	node.Content = nil
+	f.changed = true
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil if absent
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// appendMapping adds a key/value pair to a mapping node and returns the value node
+func appendMapping(node *yaml.Node, key string, value *yaml.Node) *yaml.Node {
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
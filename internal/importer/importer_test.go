@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMessages(t *testing.T, content string) (string, []model.MessageSource) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "messages.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	location := model.SourceLocation{File: path}
+	return path, []model.MessageSource{
+		{ID: "Hello", Location: location},
+		{ID: "UserCount", Location: location},
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	t.Run("merges translations and keeps comments", func(t *testing.T) {
+		path, messages := writeMessages(t, `# Greeting on the home page
+Hello:
+  ja: "こんにちは {{.name}}"
+UserCount:
+  ja: "{{.Count}}人のユーザー"
+  en:
+    one: "{{.Count}} user"
+    other: "{{.Count}} users"
+`)
+
+		csvContent := `id,form,description,ja,en
+Hello,,Greeting on the home page,こんにちは {{.name}},Hello {{.name}}
+UserCount,one,,,{{.Count}} member
+UserCount,other,,{{.Count}}人のユーザー,{{.Count}} members
+`
+		written, err := ImportCSV(strings.NewReader(csvContent), messages, []string{"ja", "en"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{path}, written)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `# Greeting on the home page
+Hello:
+  ja: "こんにちは {{.name}}"
+  en: "Hello {{.name}}"
+UserCount:
+  ja: "{{.Count}}人のユーザー"
+  en:
+    one: "{{.Count}} member"
+    other: "{{.Count}} members"
+`, string(content))
+	})
+
+	t.Run("leaves files without changes untouched", func(t *testing.T) {
+		_, messages := writeMessages(t, `Hello:
+  en: "Hello"
+UserCount:
+  en: "{{.Count}} users"
+`)
+
+		csvContent := `id,form,description,en
+Hello,,,Hello
+UserCount,other,,{{.Count}} users
+`
+		written, err := ImportCSV(strings.NewReader(csvContent), messages, []string{"en"})
+		require.NoError(t, err)
+		assert.Empty(t, written)
+	})
+
+	t.Run("turns plain templates into plural forms", func(t *testing.T) {
+		path, messages := writeMessages(t, `UserCount:
+  en: "{{.Count}} users"
+`)
+
+		csvContent := `id,form,description,en
+UserCount,one,,{{.Count}} user
+`
+		_, err := ImportCSV(strings.NewReader(csvContent), messages, []string{"en"})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `UserCount:
+  en:
+    other: "{{.Count}} users"
+    one: "{{.Count}} user"
+`, string(content))
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		_, messages := writeMessages(t, `Hello:
+  en: "Hello"
+UserCount:
+  en:
+    other: "{{.Count}} users"
+`)
+
+		tests := []struct {
+			name     string
+			csv      string
+			expected string
+		}{
+			{"header", "id,en\nHello,Hi\n", "invalid CSV header"},
+			{"unconfigured locale", "id,form,description,de\nHello,,,Hallo\n", `CSV column "de" is not a configured locale`},
+			{"unknown message", "id,form,description,en\nBye,,,Bye\n", `line 2: unknown message "Bye"`},
+			{"invalid form", "id,form,description,en\nUserCount,lots,,many\n", `invalid plural form "lots"`},
+			{"plain row for plural", "id,form,description,en\nUserCount,,,users\n", "use one row per plural form"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := ImportCSV(strings.NewReader(tt.csv), messages, []string{"en"})
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+}
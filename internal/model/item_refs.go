@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// itemRefPattern matches the call form of a placeholder reference, e.g. {{entity "user"}}
+var itemRefPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s+"([^"\\]*)"\s*\}\}`)
+
+// ItemRef is a placeholder item referenced by ID in a message, as in {{entity "user"}}.
+// It is rendered like a field but fixed at generation time instead of passed to the constructor.
+type ItemRef struct {
+	Kind string
+	ID   string
+}
+
+// TemplateKey returns the template data key the reference is rewritten to
+func (r ItemRef) TemplateKey() string {
+	return r.Kind + "__" + r.ID
+}
+
+// ResolveItemRefs rewrites every {{kind "id"}} reference to an item of a localized placeholder
+// kind into the field {{.kind__id}} and records it in the ItemRefs of the message. Calls of
+// names that are not placeholder kinds are left to template function validation.
+// messages is not modified, so its templates can still be exported as written.
+func ResolveItemRefs(messages []MessageSource, placeholders []PlaceholderSource) ([]MessageSource, error) {
+	items := make(map[string]map[string]map[string]string) // kind -> item ID -> locale -> text
+	for _, ph := range placeholders {
+		for _, localeMap := range ph.Items {
+			if len(localeMap) > 0 {
+				items[ph.Kind] = ph.Items
+				break
+			}
+		}
+	}
+	if len(items) == 0 {
+		return messages, nil
+	}
+
+	resolved := make([]MessageSource, len(messages))
+	copy(resolved, messages)
+	for i := range resolved {
+		msg := &resolved[i]
+		msg.Variants = append([]MessageVariant(nil), msg.Variants...)
+		refs := make(map[string]ItemRef)
+		var refErr error
+		rewrite := func(template string) string {
+			return itemRefPattern.ReplaceAllStringFunc(template, func(call string) string {
+				match := itemRefPattern.FindStringSubmatch(call)
+				kindItems, ok := items[match[1]]
+				if !ok {
+					return call
+				}
+				if _, exists := kindItems[match[2]]; !exists && refErr == nil {
+					refErr = fmt.Errorf("message %q (%s): %s refers to unknown %s item %q",
+						msg.ID, msg.Location, call, match[1], match[2])
+				}
+				ref := ItemRef{Kind: match[1], ID: match[2]}
+				refs[ref.TemplateKey()] = ref
+				return "{{." + ref.TemplateKey() + "}}"
+			})
+		}
+
+		msg.Templates = rewriteTemplates(msg.Templates, rewrite)
+		msg.RawTemplates = rewriteRawTemplates(msg.RawTemplates, rewrite)
+		for j := range msg.Variants {
+			msg.Variants[j].Templates = rewriteTemplates(msg.Variants[j].Templates, rewrite)
+			msg.Variants[j].RawTemplates = rewriteRawTemplates(msg.Variants[j].RawTemplates, rewrite)
+		}
+		if refErr != nil {
+			return nil, refErr
+		}
+
+		for _, info := range msg.FieldInfos {
+			if _, exists := refs[info.GenerateTemplateKey()]; exists {
+				return nil, fmt.Errorf(
+					"message %q (%s): placeholder {{.%s}} collides with an item reference of the same name: rename the placeholder",
+					msg.ID, msg.Location, info.String())
+			}
+		}
+		msg.ItemRefs = nil
+		for _, ref := range refs {
+			msg.ItemRefs = append(msg.ItemRefs, ref)
+		}
+		sort.Slice(msg.ItemRefs, func(a, b int) bool {
+			return msg.ItemRefs[a].TemplateKey() < msg.ItemRefs[b].TemplateKey()
+		})
+	}
+	return resolved, nil
+}
+
+// rewriteTemplates returns a copy of templates with rewrite applied to every template
+func rewriteTemplates(templates map[string]string, rewrite func(string) string) map[string]string {
+	if templates == nil {
+		return nil
+	}
+	result := make(map[string]string, len(templates))
+	for locale, template := range templates {
+		result[locale] = rewrite(template)
+	}
+	return result
+}
+
+// rewriteRawTemplates returns a copy of raw templates with rewrite applied to every template and plural form
+func rewriteRawTemplates(raw map[string]interface{}, rewrite func(string) string) map[string]interface{} {
+	if raw == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(raw))
+	for locale, value := range raw {
+		switch v := value.(type) {
+		case string:
+			result[locale] = rewrite(v)
+		case map[string]interface{}:
+			forms := make(map[string]interface{}, len(v))
+			for form, text := range v {
+				if s, ok := text.(string); ok {
+					forms[form] = rewrite(s)
+				} else {
+					forms[form] = text
+				}
+			}
+			result[locale] = forms
+		default:
+			result[locale] = value
+		}
+	}
+	return result
+}
@@ -2,6 +2,8 @@
 package model
 
 import (
+	"fmt"
+	"go/token"
 	"regexp"
 	"sort"
 	"strings"
@@ -16,16 +18,46 @@ var (
 	digitStartPattern = regexp.MustCompile(`^\d`)
 )
 
+// reservedFieldNames are the methods generated on message structs, and the fields whose
+// With<Field> setter would be one of them; such fields are renamed with reservedFieldSuffix
+var reservedFieldNames = map[string]bool{
+	"Localize":        true,
+	"LocalizeWith":    true,
+	"WithPluralCount": true,
+	"WithVariant":     true,
+	"ID":              true,
+	"MessageID":       true,
+	"String":          true,
+	"PluralCount":     true,
+	"Variant":         true,
+}
+
+// errorFieldNames are reserved like reservedFieldNames when implement_error generates
+// the Error, Unwrap and WithCause methods
+var errorFieldNames = map[string]bool{
+	"Error":     true,
+	"Unwrap":    true,
+	"WithCause": true,
+	"Cause":     true,
+}
+
+const reservedFieldSuffix = "Field"
+
 // FieldInfo represents a field with optional suffix for enhanced naming
 type FieldInfo struct {
-	Name   string // Base field name (e.g., "entity")
-	Suffix string // Optional suffix (e.g., "from", "1", "user")
+	Name      string // Base field name (e.g., "entity")
+	Suffix    string // Optional suffix (e.g., "from", "1", "user")
+	Separator string // Separator between name and suffix; empty means config.DefaultSuffixSeparator
 }
 
 // String returns the field identifier for template processing
 func (f FieldInfo) String() string {
 	if f.Suffix != "" {
-		return f.Name + ":" + f.Suffix
+		separator := f.Separator
+		if separator == "" {
+			separator = config.DefaultSuffixSeparator
+		}
+		return f.Name + separator + f.Suffix
 	}
 	return f.Name
 }
@@ -46,21 +78,70 @@ func (f FieldInfo) GenerateTemplateKey() string {
 	return f.Name
 }
 
+// SourceLocation identifies where a message or placeholder item is defined
+type SourceLocation struct {
+	File string
+	Line int // 1-based; 0 when unknown
+}
+
+// String formats the location as file:line
+func (l SourceLocation) String() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
 type MessageSource struct {
 	ID           string
-	Templates    map[string]string      // locale -> template (simplified for processing)
+	Templates    map[string]string            // locale -> template (simplified for processing)
+	RawTemplates map[string]interface{}       // locale -> raw template data (preserves plural forms)
+	FieldInfos   []FieldInfo                  // Enhanced field information with suffix support
+	Position     int                          // Order of appearance across source files
+	Location     SourceLocation               // File and line where the message is defined
+	Description  string                       // Comment attached to the message in the source file
+	Autofilled   map[string]string            // locale -> source locale the template was copied from by autofill_from
+	Metadata     map[string]map[string]string // locale -> go-i18n message hint (e.g. leftDelim) -> value
+	Variants     []MessageVariant             // Alternate phrasings selected at runtime, sorted by name
+	Markdown     bool                         // Text is rendered from markdown to HTML, with placeholder values escaped
+	ItemRefs     []ItemRef                    // Placeholder items referenced by ID, set by ResolveItemRefs
+	Context      MessageContext               // Translator context exported to XLIFF notes
+	Tags         []string                     // Labels selecting the message with the tags option
+	NoPlural     []string                     // Locales of a plural message using a single text for every count
+	Backend      string                       // Backend declared by the message, overriding the backend option; empty if none
+	CountHint    string                       // Expected magnitude of the count of a plural message, for translators
+}
+
+// MessageContext gives translators context for a message
+type MessageContext struct {
+	Note       string // Free-form explanation of where and how the message is shown
+	Screenshot string // Path or URL of an image showing the message
+}
+
+// MessageVariant is an alternate phrasing of a message, e.g. for A/B testing
+type MessageVariant struct {
+	Name         string
+	Templates    map[string]string      // locale -> template
 	RawTemplates map[string]interface{} // locale -> raw template data (preserves plural forms)
-	FieldInfos   []FieldInfo            // Enhanced field information with suffix support
 }
 
 type PlaceholderSource struct {
-	Kind  string
-	Items map[string]map[string]string // ID -> locale -> string
+	Kind             string
+	Items            map[string]map[string]string            // ID -> locale -> string
+	Position         int                                     // Order of appearance across source files
+	ItemOrder        []string                                // Item IDs in source order
+	Description      string                                  // Comment at the top of the source file
+	ItemDescriptions map[string]string                       // ID -> comment attached to the item
+	ItemLocations    map[string]SourceLocation               // ID -> file and line of the first definition
+	PluralItems      map[string]map[string]map[string]string // ID -> locale -> plural form -> string
+	Autofilled       map[string]map[string]string            // ID -> locale -> source locale the text was copied from
+	SimpleFormat     bool                                    // Some items were read from simple-format (name.locale.ext) files
 }
 
 type Definitions struct {
-	Messages     []templatex.Message
-	Placeholders []templatex.Placeholder
+	Messages            []templatex.Message
+	Placeholders        []templatex.Placeholder
+	RuntimePlaceholders []templatex.RuntimePlaceholder
 }
 
 // generateStructName generates a valid Go struct name from a message ID
@@ -75,15 +156,27 @@ func generateStructName(id string) string {
 
 func Build(messages []MessageSource, placeholders []PlaceholderSource, locales []string, cfg *config.Config) (*Definitions, error) {
 	defs := Definitions{}
+	sortBySource := cfg.SortBySource()
+
+	// Follow source order when requested, otherwise output is sorted alphabetically at the end
+	if sortBySource {
+		messages = sortedByPosition(messages, func(m MessageSource) int { return m.Position })
+		placeholders = sortedByPosition(placeholders, func(p PlaceholderSource) int { return p.Position })
+	}
 
-	// Determine primary locale (first locale in configuration)
+	// Determine primary locale (primary_locale, or the first locale in configuration)
 	primaryLocale := "en" // Default fallback
-	if len(locales) > 0 {
+	if cfg.PrimaryLocale != "" {
+		primaryLocale = cfg.PrimaryLocale
+	} else if len(locales) > 0 {
 		primaryLocale = locales[0]
 	}
 
 	// Build placeholder definitions
 	placeholderTypes := map[string]string{}
+	pluralTypes := map[string]bool{} // type name -> has plural forms
+	valueTypes := map[string]bool{}  // type names created from a string with New<Type>
+	enumTypes := map[string]bool{}   // type names created from a string with Parse<Type>
 	for _, ph := range placeholders {
 		// Determine if it's a Value placeholder (no localization)
 		isValue := true
@@ -97,24 +190,43 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 		// Generate type name based on whether it has localization
 		var typeName string
 		if isValue {
-			typeName = utils.ToCamelCase(ph.Kind) + "Value"
+			typeName = utils.ToCamelCase(ph.Kind) + cfg.GetValueSuffix()
 		} else {
 			typeName = utils.ToCamelCase(ph.Kind) + "Text"
 		}
 		varName := ph.Kind + "Templates"
 
+		// Typed ID constants are named after the kind, e.g. EntityID and EntityUser
+		var idType string
+		if cfg.PlaceholderConsts && !isValue {
+			idType = utils.ToCamelCase(ph.Kind) + "ID"
+		}
+
+		// The ID listing is named after the kind too, e.g. EntityIDs
+		var idsFunc string
+		if !isValue {
+			idsFunc = utils.ToCamelCase(ph.Kind) + "IDs"
+		}
+
 		// Generate items for utility access
 		var items []templatex.PlaceholderItem
-		for id, templates := range ph.Items {
-			items = append(items, templatex.PlaceholderItem{
-				ID:        id,
-				FieldName: utils.ToCamelCase(id),
-				Templates: templates,
-			})
+		for _, id := range placeholderItemIDs(ph) {
+			item := templatex.PlaceholderItem{
+				ID:          id,
+				FieldName:   utils.ToCamelCase(id),
+				Templates:   ph.Items[id],
+				Description: ph.ItemDescriptions[id],
+				PluralForms: ph.PluralItems[id],
+				Autofilled:  ph.Autofilled[id],
+			}
+			if idType != "" {
+				item.ConstName = utils.ToCamelCase(ph.Kind) + item.FieldName
+			}
+			items = append(items, item)
 		}
 
 		// Sort items by their localized text in primary locale for consistent ordering
-		if !isValue {
+		if !isValue && !sortBySource {
 			sort.Slice(items, func(i, j int) bool {
 				// Get localized text for primary locale, fallback to ID if not available
 				textI := ph.Items[items[i].ID][primaryLocale]
@@ -134,11 +246,18 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 		}
 
 		defs.Placeholders = append(defs.Placeholders, templatex.Placeholder{
-			StructName: typeName,
-			VarName:    varName,
-			IsValue:    isValue,
-			Items:      items,
+			Kind:        ph.Kind,
+			StructName:  typeName,
+			VarName:     varName,
+			IsValue:     isValue,
+			Items:       items,
+			Description: ph.Description,
+			HasPlural:   len(ph.PluralItems) > 0,
+			IDType:      idType,
+			IDsFunc:     idsFunc,
 		})
+		pluralTypes[typeName] = len(ph.PluralItems) > 0
+		valueTypes[typeName] = isValue
 
 		// Map the kind itself to the type (for {{.entity}} usage)
 		placeholderTypes[ph.Kind] = typeName
@@ -149,10 +268,48 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 		}
 	}
 
+	// Enum placeholders render their value as-is but only accept the values listed in the config
+	for _, kind := range cfg.EnumPlaceholderNames() {
+		if _, exists := placeholderTypes[kind]; exists {
+			return nil, fmt.Errorf(
+				"enum placeholder %q is also defined in a placeholder file: remove one of the definitions", kind)
+		}
+		enum, err := buildEnumPlaceholder(kind, cfg.EnumPlaceholders[kind])
+		if err != nil {
+			return nil, err
+		}
+		defs.Placeholders = append(defs.Placeholders, enum)
+		placeholderTypes[kind] = enum.StructName
+		enumTypes[enum.StructName] = true
+	}
+
+	// Runtime placeholders are resolved by provider variables instead of placeholder files
+	runtimeProviders := make(map[string]string)
+	for _, name := range cfg.RuntimePlaceholderNames() {
+		if _, exists := placeholderTypes[name]; exists {
+			return nil, fmt.Errorf(
+				"runtime placeholder %q is also defined in a placeholder file: remove one of the definitions", name)
+		}
+		provider := utils.ToCamelCase(name) + "Provider"
+		runtimeProviders[name] = provider
+		defs.RuntimePlaceholders = append(defs.RuntimePlaceholders, templatex.RuntimePlaceholder{
+			Name:     name,
+			Provider: provider,
+		})
+	}
+
+	// Variants become go-i18n messages named <message ID>.<variant>, next to the regular messages
+	messageIDs := make(map[string]bool, len(messages))
+	for _, msg := range messages {
+		messageIDs[msg.ID] = true
+	}
+
 	// Build message definitions
 	for _, msg := range messages {
 		structName := generateStructName(msg.ID)
 		var fields []templatex.Field
+		var runtimeFields []templatex.RuntimeField
+		fieldSources := make(map[string]string) // generated field name -> placeholder notation
 
 		// Process FieldInfos to generate fields
 		for _, fieldInfo := range msg.FieldInfos {
@@ -161,23 +318,52 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 				continue
 			}
 
-			fieldName := fieldInfo.GenerateFieldName()
+			fieldName := safeFieldName(fieldInfo.GenerateFieldName())
+			if cfg.ImplementError && errorFieldNames[fieldName] {
+				fieldName += reservedFieldSuffix
+			}
 			templateKey := fieldInfo.GenerateTemplateKey()
 
+			if provider, ok := runtimeProviders[fieldInfo.Name]; ok {
+				runtimeFields = append(runtimeFields, templatex.RuntimeField{TemplateKey: templateKey, Provider: provider})
+				continue
+			}
+
+			// Distinct placeholders must not collapse into the same Go field
+			if source, exists := fieldSources[fieldName]; exists && source != fieldInfo.String() {
+				return nil, fmt.Errorf(
+					"message %q: placeholders {{.%s}} and {{.%s}} both generate field %q: rename one of them",
+					msg.ID, source, fieldInfo.String(), fieldName)
+			}
+			fieldSources[fieldName] = fieldInfo.String()
+
 			// Determine the base field name for type lookup
 			baseFieldName := fieldInfo.Name
 			typ, ok := placeholderTypes[baseFieldName]
-			if !ok {
+			plainValue := !ok && cfg.PlainValues()
+			if plainValue {
+				// Field not found in placeholder definitions, pass it as a plain string
+				typ = "string"
+			} else if !ok {
 				// Field not found in placeholder definitions, treat as Value type
-				typ = utils.ToCamelCase(baseFieldName) + "Value"
+				typ = utils.ToCamelCase(baseFieldName) + cfg.GetValueSuffix()
+				valueTypes[typ] = true
 
 				// Add to placeholder definitions if not already present
 				placeholderAlreadyExists := false
 				for _, ph := range defs.Placeholders {
-					if ph.StructName == typ {
-						placeholderAlreadyExists = true
-						break
+					if ph.StructName != typ {
+						continue
 					}
+					// A value_suffix such as "" can name the value type like another placeholder type
+					if ph.VarName != baseFieldName+"Templates" {
+						return nil, fmt.Errorf(
+							"message %q: value placeholder {{.%s}} generates type %s, which collides with placeholder %q: "+
+								"rename the placeholder or change value_suffix",
+							msg.ID, baseFieldName, typ, ph.Kind)
+					}
+					placeholderAlreadyExists = true
+					break
 				}
 				if !placeholderAlreadyExists {
 					// For auto-generated value types, create single item
@@ -200,9 +386,42 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 				FieldName:   fieldName,
 				Type:        typ,
 				TemplateKey: templateKey,
+				Plural:      pluralTypes[typ],
+				PlainValue:  plainValue,
+				Value:       valueTypes[typ],
+				Enum:        enumTypes[typ],
+			})
+		}
+
+		var itemRefs []templatex.ItemRef
+		for _, ref := range msg.ItemRefs {
+			typ := placeholderTypes[ref.Kind]
+			itemRefs = append(itemRefs, templatex.ItemRef{
+				TemplateKey: ref.TemplateKey(),
+				Type:        typ,
+				ID:          ref.ID,
+				Plural:      pluralTypes[typ],
 			})
 		}
 
+		// Each field gets a With<Field> setter, which must not collide with another field
+		for _, field := range fields {
+			for _, other := range fields {
+				if other.FieldName == "With"+field.FieldName {
+					return nil, fmt.Errorf(
+						"message %q: the setter With%s of field %s collides with the field of the same name: rename one of the placeholders",
+						msg.ID, field.FieldName, field.FieldName)
+				}
+			}
+		}
+
+		if err := checkTemplateFuncs(msg, cfg.TemplateFunctions); err != nil {
+			return nil, err
+		}
+		if err := checkSuffixReferences(msg, cfg.SuffixSeparator); err != nil {
+			return nil, err
+		}
+
 		// Process templates to handle suffix-based or duplicate placeholders
 		originalTemplates := msg.Templates
 		if originalTemplates == nil {
@@ -213,9 +432,34 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 		processedTemplates := ProcessMessageTemplatesWithFieldInfos(originalTemplates, msg.FieldInfos)
 
 		// Check if message supports count (has pluralization)
-		supportsCount := messageSupportsCount(originalTemplates, cfg)
+		supportsCount := messageSupportsCount(originalTemplates, cfg) || hasPluralForms(msg.RawTemplates)
+		for _, variant := range msg.Variants {
+			supportsCount = supportsCount || hasPluralForms(variant.RawTemplates)
+		}
 		pluralPlaceholder := getMessagePluralPlaceholder(originalTemplates, cfg)
 
+		var paramsType string
+		if cfg.ParamsMinFields > 0 && len(fields) >= cfg.ParamsMinFields {
+			paramsType = structName + "Params"
+		}
+
+		variants := make([]templatex.MessageVariant, 0, len(msg.Variants))
+		for _, variant := range msg.Variants {
+			variantID := msg.ID + "." + variant.Name
+			if messageIDs[variantID] {
+				return nil, fmt.Errorf(
+					"message %q: variant %q has the same ID as message %q: rename one of them",
+					msg.ID, variant.Name, variantID)
+			}
+			variants = append(variants, templatex.MessageVariant{
+				Name:         variant.Name,
+				ID:           variantID,
+				Templates:    ProcessMessageTemplatesWithFieldInfos(variant.Templates, msg.FieldInfos),
+				RawTemplates: variant.RawTemplates,
+				UsesFuncs:    usesTemplateFuncs(variant.RawTemplates, msg.Metadata, cfg.TemplateFunctions),
+			})
+		}
+
 		defs.Messages = append(defs.Messages, templatex.Message{
 			ID:                msg.ID,
 			StructName:        structName,
@@ -224,21 +468,308 @@ func Build(messages []MessageSource, placeholders []PlaceholderSource, locales [
 			RawTemplates:      msg.RawTemplates,
 			SupportsCount:     supportsCount,
 			PluralPlaceholder: pluralPlaceholder,
+			ParamsType:        paramsType,
+			Autofilled:        msg.Autofilled,
+			RuntimeFields:     runtimeFields,
+			Metadata:          msg.Metadata,
+			Variants:          variants,
+			UsesFuncs:         usesTemplateFuncs(msg.RawTemplates, msg.Metadata, cfg.TemplateFunctions),
+			Markdown:          msg.Markdown,
+			ItemRefs:          itemRefs,
+			Backend:           msg.Backend,
+			CountHint:         msg.CountHint,
 		})
 	}
 
-	// Sort for consistent output (CI-friendly)
-	sort.Slice(defs.Messages, func(i, j int) bool {
-		return defs.Messages[i].ID < defs.Messages[j].ID
-	})
+	if err := checkGeneratedNameCollisions(&defs, cfg); err != nil {
+		return nil, err
+	}
+	if err := checkTypeNameCollisions(&defs); err != nil {
+		return nil, err
+	}
 
-	sort.Slice(defs.Placeholders, func(i, j int) bool {
-		return defs.Placeholders[i].StructName < defs.Placeholders[j].StructName
-	})
+	// Sort for consistent output (CI-friendly); source order is already deterministic
+	if !sortBySource {
+		sort.Slice(defs.Messages, func(i, j int) bool {
+			return defs.Messages[i].ID < defs.Messages[j].ID
+		})
+
+		sort.Slice(defs.Placeholders, func(i, j int) bool {
+			return defs.Placeholders[i].StructName < defs.Placeholders[j].StructName
+		})
+	}
 
 	return &defs, nil
 }
 
+// generatedNames lists the fixed package-level names the template emits, with the option
+// generating them; enabled reports whether the option is in effect, nil meaning always.
+// Every name the template adds must be listed here so messages and placeholders cannot shadow it.
+var generatedNames = []struct {
+	names   []string
+	option  string
+	enabled func(defs *Definitions, cfg *config.Config) bool
+}{
+	{names: []string{"Localizable", "TemplateFuncs", "PlaceholderKinds", "MessageFields"}},
+	{names: []string{"Localizer", "NewLocalizer"}, option: "localizer",
+		enabled: func(_ *Definitions, cfg *config.Config) bool { return cfg.Localizer }},
+	{names: []string{"TranslationsDir", "LoadTranslations", "WatchTranslations"}, option: "backend: filesystem",
+		enabled: func(defs *Definitions, cfg *config.Config) bool {
+			if cfg.Backend == config.BackendFilesystem || cfg.DataLayout == config.DataLayoutExternal {
+				return true
+			}
+			for _, msg := range defs.Messages {
+				if msg.Backend == config.BackendFilesystem {
+					return true
+				}
+			}
+			return false
+		}},
+	{names: []string{"CustomFuncs"}, option: "template_functions",
+		enabled: func(_ *Definitions, cfg *config.Config) bool { return len(cfg.TemplateFunctions) > 0 }},
+	{names: []string{"UnknownVariantHook"}, option: "variants",
+		enabled: func(defs *Definitions, _ *config.Config) bool {
+			for _, msg := range defs.Messages {
+				if len(msg.Variants) > 0 {
+					return true
+				}
+			}
+			return false
+		}},
+	{names: []string{"LocalizeByID", "NewLocalizableByID", "ValidateParams"}, option: "emit_localize_by_id",
+		enabled: func(_ *Definitions, cfg *config.Config) bool { return cfg.LocalizeByID }},
+	{names: []string{"AllMessageIDs", "MessageCoverage"}, option: "emit_coverage",
+		enabled: func(_ *Definitions, cfg *config.Config) bool { return cfg.Coverage }},
+	{names: []string{"LocaleInfo"}, option: "emit_locale_info",
+		enabled: func(_ *Definitions, cfg *config.Config) bool { return cfg.LocaleInfo }},
+	{names: []string{"TestGeneratedMessages"}, option: "output_test",
+		enabled: func(_ *Definitions, cfg *config.Config) bool { return cfg.OutputTest }},
+}
+
+// checkGeneratedNameCollisions rejects messages and placeholders whose type or constructor is
+// named like a fixed name in generatedNames; the generated package would not compile
+func checkGeneratedNameCollisions(defs *Definitions, cfg *config.Config) error {
+	reserved := make(map[string]string) // generated name -> option generating it
+	for _, entry := range generatedNames {
+		if entry.enabled != nil && !entry.enabled(defs, cfg) {
+			continue
+		}
+		for _, name := range entry.names {
+			reserved[name] = entry.option
+		}
+	}
+	collision := func(name string) string {
+		option, exists := reserved[name]
+		if !exists {
+			return ""
+		}
+		if option == "" {
+			return fmt.Sprintf("%s, which is always generated", name)
+		}
+		return fmt.Sprintf("%s, which is generated for %s", name, option)
+	}
+
+	for _, msg := range defs.Messages {
+		for _, name := range []string{msg.StructName, "New" + msg.StructName} {
+			if found := collision(name); found != "" {
+				return fmt.Errorf("message %q generates %s: rename the message (e.g. %q)", msg.ID, found, msg.ID+"Message")
+			}
+		}
+	}
+	for _, ph := range defs.Placeholders {
+		for _, name := range []string{ph.StructName, "New" + ph.StructName} {
+			found := collision(name)
+			if found == "" {
+				continue
+			}
+			if ph.Kind == "" { // Value types generated for a message field
+				return fmt.Errorf("value placeholder {{.%s}} generates %s: rename the placeholder or change value_suffix",
+					ph.Items[0].ID, found)
+			}
+			return fmt.Errorf("placeholder %q generates %s: rename the placeholder", ph.Kind, found)
+		}
+	}
+	for _, rp := range defs.RuntimePlaceholders {
+		if found := collision(rp.Provider); found != "" {
+			return fmt.Errorf("runtime placeholder %q generates %s: rename the runtime placeholder", rp.Name, found)
+		}
+	}
+	return nil
+}
+
+// checkTypeNameCollisions rejects messages whose struct name matches, ignoring case, a type,
+// accessor or ID constant generated for a placeholder; such names shadow each other or are
+// easily confused. ID constants must also be distinct from the other placeholder names,
+// and params types and runtime providers from every placeholder and message type.
+func checkTypeNameCollisions(defs *Definitions) error {
+	placeholderNames := make(map[string]string) // lowercased name -> generated name
+	for _, ph := range defs.Placeholders {
+		// A value_suffix such as "" or "Text" can name a value type like another placeholder type
+		if existing, exists := placeholderNames[strings.ToLower(ph.StructName)]; exists {
+			return fmt.Errorf(
+				"placeholder type %s collides with %s: rename one of the placeholders or change value_suffix",
+				ph.StructName, existing)
+		}
+		placeholderNames[strings.ToLower(ph.StructName)] = ph.StructName
+		if !ph.IsValue && !ph.IsEnum {
+			accessor := ph.StructName + "s"
+			placeholderNames[strings.ToLower(accessor)] = accessor
+		}
+		if ph.IDsFunc != "" {
+			placeholderNames[strings.ToLower(ph.IDsFunc)] = ph.IDsFunc
+		}
+	}
+	// ID constants share the package namespace with every other generated name
+	for _, ph := range defs.Placeholders {
+		if ph.IsEnum {
+			for _, name := range append([]string{"Parse" + ph.StructName}, itemConstNames(ph)...) {
+				if existing, exists := placeholderNames[strings.ToLower(name)]; exists {
+					return fmt.Errorf(
+						"enum placeholder %q generates %s, which collides with %s: rename the placeholder or the value",
+						ph.Kind, name, existing)
+				}
+				placeholderNames[strings.ToLower(name)] = name
+			}
+			continue
+		}
+		if ph.IDType == "" {
+			continue
+		}
+		for _, name := range append([]string{ph.IDType}, itemConstNames(ph)...) {
+			if existing, exists := placeholderNames[strings.ToLower(name)]; exists {
+				return fmt.Errorf(
+					"placeholder %q generates constant %s, which collides with %s: "+
+						"rename the placeholder item or disable emit_placeholder_consts",
+					ph.Kind, name, existing)
+			}
+			placeholderNames[strings.ToLower(name)] = name
+		}
+	}
+
+	for _, msg := range defs.Messages {
+		name, exists := placeholderNames[strings.ToLower(msg.StructName)]
+		if !exists {
+			continue
+		}
+		return fmt.Errorf(
+			"message %q generates type %s, which collides with the placeholder type %s: "+
+				"rename the message (e.g. %q)",
+			msg.ID, msg.StructName, name, msg.ID+"Message")
+	}
+
+	// Params structs share the namespace with placeholder types and the other messages
+	for _, msg := range defs.Messages {
+		placeholderNames[strings.ToLower(msg.StructName)] = msg.StructName
+	}
+	for _, rp := range defs.RuntimePlaceholders {
+		if name, exists := placeholderNames[strings.ToLower(rp.Provider)]; exists {
+			return fmt.Errorf(
+				"runtime placeholder %q generates variable %s, which collides with %s: rename one of them",
+				rp.Name, rp.Provider, name)
+		}
+	}
+	for _, msg := range defs.Messages {
+		if msg.ParamsType == "" {
+			continue
+		}
+		if name, exists := placeholderNames[strings.ToLower(msg.ParamsType)]; exists {
+			return fmt.Errorf(
+				"message %q generates params type %s, which collides with %s: "+
+					"rename one of them or raise params_constructor_min_fields",
+				msg.ID, msg.ParamsType, name)
+		}
+	}
+	return nil
+}
+
+// buildEnumPlaceholder creates the definition of an enum placeholder with one constant per value,
+// named after the kind and the value (e.g. StatusPending)
+func buildEnumPlaceholder(kind string, values []string) (templatex.Placeholder, error) {
+	typeName := utils.ToCamelCase(kind)
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return templatex.Placeholder{}, fmt.Errorf("enum placeholder %q does not generate a valid Go type name", kind)
+	}
+	if len(values) == 0 {
+		return templatex.Placeholder{}, fmt.Errorf("enum placeholder %q must list at least one value", kind)
+	}
+
+	items := make([]templatex.PlaceholderItem, 0, len(values))
+	seen := make(map[string]string, len(values)) // constant name -> value
+	for _, value := range values {
+		constName := typeName + utils.ToCamelCase(value)
+		if value == "" || !token.IsIdentifier(constName) {
+			return templatex.Placeholder{}, fmt.Errorf(
+				"enum placeholder %q: value %q does not generate a valid Go identifier", kind, value)
+		}
+		if other, exists := seen[constName]; exists {
+			return templatex.Placeholder{}, fmt.Errorf(
+				"enum placeholder %q: values %q and %q both generate %s", kind, other, value, constName)
+		}
+		seen[constName] = value
+		items = append(items, templatex.PlaceholderItem{
+			ID:        value,
+			FieldName: utils.ToCamelCase(value),
+			ConstName: constName,
+		})
+	}
+
+	return templatex.Placeholder{
+		Kind:       kind,
+		StructName: typeName,
+		VarName:    kind + "Templates",
+		IsEnum:     true,
+		Items:      items,
+		IDsFunc:    typeName + "IDs",
+	}, nil
+}
+
+func itemConstNames(ph templatex.Placeholder) []string {
+	names := make([]string, 0, len(ph.Items))
+	for _, item := range ph.Items {
+		names = append(names, item.ConstName)
+	}
+	return names
+}
+
+// safeFieldName renames generated field names that would collide with generated message methods
+func safeFieldName(name string) string {
+	if reservedFieldNames[name] {
+		return name + reservedFieldSuffix
+	}
+	return name
+}
+
+// sortedByPosition returns a copy of the sources ordered by their source position
+func sortedByPosition[T any](sources []T, position func(T) int) []T {
+	sorted := make([]T, len(sources))
+	copy(sorted, sources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return position(sorted[i]) < position(sorted[j])
+	})
+	return sorted
+}
+
+// placeholderItemIDs returns the item IDs of a placeholder in source order when known,
+// falling back to alphabetical order for items without recorded positions
+func placeholderItemIDs(ph PlaceholderSource) []string {
+	ids := make([]string, 0, len(ph.Items))
+	seen := make(map[string]bool, len(ph.Items))
+	for _, id := range ph.ItemOrder {
+		if _, ok := ph.Items[id]; ok && !seen[id] {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+	var rest []string
+	for id := range ph.Items {
+		if !seen[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	return append(ids, rest...)
+}
+
 // messageSupportsCount checks if a message has plural forms in any locale
 func messageSupportsCount(templates map[string]string, cfg *config.Config) bool {
 	pluralPlaceholder := cfg.GetPluralPlaceholder()
@@ -257,6 +788,7 @@ func messageSupportsCount(templates map[string]string, cfg *config.Config) bool
 		// Templates that have "one:", "other:", "few:", etc. are typically plural
 		if strings.Contains(template, "one:") ||
 			strings.Contains(template, "other:") ||
+			strings.Contains(template, "two:") ||
 			strings.Contains(template, "few:") ||
 			strings.Contains(template, "many:") ||
 			strings.Contains(template, "zero:") {
@@ -266,11 +798,165 @@ func messageSupportsCount(templates map[string]string, cfg *config.Config) bool
 	return false
 }
 
+// checkTemplateFuncs rejects messages whose templates, including plural forms and variants,
+// call a function the generated code does not provide; go-i18n would otherwise only fail
+// when the message is rendered
+func checkTemplateFuncs(msg MessageSource, custom []string) error {
+	sources := []struct {
+		name         string
+		rawTemplates map[string]interface{}
+	}{{"", msg.RawTemplates}}
+	for _, variant := range msg.Variants {
+		sources = append(sources, struct {
+			name         string
+			rawTemplates map[string]interface{}
+		}{variant.Name, variant.RawTemplates})
+	}
+
+	for _, source := range sources {
+		for _, locale := range sortedLocales(source.rawTemplates) {
+			for _, template := range templateStrings(source.rawTemplates[locale]) {
+				name := unknownTemplateFunc(template, msg.Metadata[locale]["leftDelim"], msg.Metadata[locale]["rightDelim"], custom)
+				if name == "" {
+					continue
+				}
+				return fmt.Errorf(
+					"%s: locale %q calls unknown template function %q: use a text/template builtin, locale "+
+						"or a function listed in template_functions "+
+						"(after a field, title, upper, lower, capitalize and camelCase are also accepted)",
+					describeMessage(msg, source.name), locale, name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSuffixReferences rejects messages whose templates, including plural forms and variants,
+// use suffix notation such as {{.entity:unknown}} that matches none of the fields of the message.
+// Fields follow the template of the preferred locale, so such a reference would be left as is in
+// the generated templates and only fail when the message is rendered
+func checkSuffixReferences(msg MessageSource, suffixSeparator string) error {
+	separator := suffixSeparator
+	if separator == "" {
+		separator = config.DefaultSuffixSeparator
+	}
+	known := make(map[string]bool, len(msg.FieldInfos))
+	for _, info := range msg.FieldInfos {
+		known[info.String()] = true
+	}
+
+	sources := []struct {
+		name         string
+		rawTemplates map[string]interface{}
+	}{{"", msg.RawTemplates}}
+	for _, variant := range msg.Variants {
+		sources = append(sources, struct {
+			name         string
+			rawTemplates map[string]interface{}
+		}{variant.Name, variant.RawTemplates})
+	}
+
+	for _, source := range sources {
+		for _, locale := range sortedLocales(source.rawTemplates) {
+			for _, template := range templateStrings(source.rawTemplates[locale]) {
+				for _, match := range templateFieldSuffixPattern.FindAllStringSubmatch(template, -1) {
+					expression := match[1]
+					// Nested selectors such as {{.user.Name}} are not suffix notation
+					if !strings.Contains(expression, separator) || strings.Contains(expression, ".") || known[expression] {
+						continue
+					}
+					return fmt.Errorf(
+						"%s: locale %q references {{.%s}}, which matches no placeholder of the message: "+
+							"use the same suffixed placeholders in every locale",
+						describeMessage(msg, source.name), locale, expression)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// describeMessage names a message, or one of its variants, with its source location for error messages
+func describeMessage(msg MessageSource, variant string) string {
+	where := fmt.Sprintf("message %q", msg.ID)
+	if variant != "" {
+		where += fmt.Sprintf(" variant %q", variant)
+	}
+	if msg.Location.File != "" {
+		where += " (" + msg.Location.String() + ")"
+	}
+	return where
+}
+
+// sortedLocales returns the keys of a locale or plural form map in sorted order
+func sortedLocales(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// templateStrings returns the template of a singular message or its plural forms in sorted order
+func templateStrings(raw interface{}) []string {
+	switch raw := raw.(type) {
+	case string:
+		return []string{raw}
+	case map[string]interface{}:
+		var templates []string
+		for _, form := range sortedLocales(raw) {
+			if template, ok := raw[form].(string); ok {
+				templates = append(templates, template)
+			}
+		}
+		return templates
+	}
+	return nil
+}
+
+// usesTemplateFuncs reports whether any locale, including plural forms, calls locale or one of
+// the custom template functions, which the generated code must then render the message with
+func usesTemplateFuncs(rawTemplates map[string]interface{}, metadata map[string]map[string]string, custom []string) bool {
+	for locale, raw := range rawTemplates {
+		for _, template := range templateStrings(raw) {
+			if callsTemplateFuncs(template, metadata[locale]["leftDelim"], metadata[locale]["rightDelim"], custom) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasPluralForms reports whether any locale defines the message with CLDR plural categories
+func hasPluralForms(rawTemplates map[string]interface{}) bool {
+	for _, raw := range rawTemplates {
+		forms, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for form := range forms {
+			if utils.IsPluralCategory(form) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getMessagePluralPlaceholder returns the plural placeholder key used in a message, or empty string if none
 func getMessagePluralPlaceholder(templates map[string]string, cfg *config.Config) string {
 	pluralPlaceholder := cfg.GetPluralPlaceholder()
 
-	for _, template := range templates {
+	// Visit locales in sorted order so the casing picked is the same on every run
+	locales := make([]string, 0, len(templates))
+	for locale := range templates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	for _, locale := range locales {
+		template := templates[locale]
 		// Find the exact case-sensitive match in the template
 		// Create regex pattern to match {{.placeholder}} and capture the actual case used
 		pattern := `\{\{\s*\.\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`
@@ -0,0 +1,631 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ModelTestSuite struct {
+	suite.Suite
+	testConfig *config.Config
+}
+
+func (s *ModelTestSuite) SetupSuite() {
+	s.testConfig = &config.Config{
+		Locales:           []string{"ja", "en"},
+		Compound:          true,
+		PluralPlaceholder: "Count",
+	}
+}
+
+func (s *ModelTestSuite) TestBuildRenamesReservedFieldNames() {
+	messages := []MessageSource{{
+		ID: "Reserved",
+		Templates: map[string]string{
+			"ja": "{{.localize}} {{.ID}} {{.name}} {{.plural_count}}",
+			"en": "{{.localize}} {{.ID}} {{.name}} {{.plural_count}}",
+		},
+		FieldInfos: []FieldInfo{{Name: "localize"}, {Name: "ID"}, {Name: "name"}, {Name: "plural_count"}},
+	}}
+
+	defs, err := Build(messages, nil, s.testConfig.Locales, s.testConfig)
+	s.Require().NoError(err)
+	s.Require().Len(defs.Messages, 1)
+
+	fields := defs.Messages[0].Fields
+	s.Require().Len(fields, 4)
+	s.Equal("LocalizeField", fields[0].FieldName)
+	s.Equal("localize", fields[0].TemplateKey)
+	s.Equal("IDField", fields[1].FieldName)
+	s.Equal("ID", fields[1].TemplateKey)
+	s.Equal("Name", fields[2].FieldName)
+	// WithPluralCount is taken, so the setter of plural_count is WithPluralCountField
+	s.Equal("PluralCountField", fields[3].FieldName)
+
+	messages[0].Templates = map[string]string{
+		"ja": "{{.string}} {{.MessageID}} {{.localize_with}}",
+		"en": "{{.string}} {{.MessageID}} {{.localize_with}}",
+	}
+	messages[0].FieldInfos = []FieldInfo{{Name: "string"}, {Name: "MessageID"}, {Name: "localize_with"}}
+	defs, err = Build(messages, nil, s.testConfig.Locales, s.testConfig)
+	s.Require().NoError(err)
+	s.Equal("StringField", defs.Messages[0].Fields[0].FieldName)
+	s.Equal("MessageIDField", defs.Messages[0].Fields[1].FieldName)
+	s.Equal("LocalizeWithField", defs.Messages[0].Fields[2].FieldName)
+}
+
+func (s *ModelTestSuite) TestBuildRenamesErrorFieldWhenImplementingError() {
+	messages := []MessageSource{{
+		ID:         "Failed",
+		Templates:  map[string]string{"ja": "{{.error}} {{.cause}}", "en": "{{.error}} {{.cause}}"},
+		FieldInfos: []FieldInfo{{Name: "error"}, {Name: "cause"}},
+	}}
+
+	defs, err := Build(messages, nil, s.testConfig.Locales, s.testConfig)
+	s.Require().NoError(err)
+	s.Equal("Error", defs.Messages[0].Fields[0].FieldName)
+
+	cfg := *s.testConfig
+	cfg.ImplementError = true
+	defs, err = Build(messages, nil, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.Equal("ErrorField", defs.Messages[0].Fields[0].FieldName)
+	s.Equal("error", defs.Messages[0].Fields[0].TemplateKey)
+	// WithCause is taken, so the setter of cause is WithCauseField
+	s.Equal("CauseField", defs.Messages[0].Fields[1].FieldName)
+}
+
+func (s *ModelTestSuite) TestBuildMarksFieldsCreatedFromStrings() {
+	messages := []MessageSource{{
+		ID:         "OrderStatus",
+		Templates:  map[string]string{"ja": "{{.order}} {{.status}}", "en": "{{.order}} {{.status}}"},
+		FieldInfos: []FieldInfo{{Name: "order"}, {Name: "status"}},
+	}}
+
+	cfg := *s.testConfig
+	cfg.EnumPlaceholders = map[string][]string{"status": {"pending", "done"}}
+	defs, err := Build(messages, nil, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.True(defs.Messages[0].Fields[0].Value)
+	s.False(defs.Messages[0].Fields[0].Enum)
+	s.False(defs.Messages[0].Fields[1].Value)
+	s.True(defs.Messages[0].Fields[1].Enum)
+}
+
+func (s *ModelTestSuite) TestBuildFieldNameCollision() {
+	tests := []struct {
+		name       string
+		template   string
+		fieldInfos []FieldInfo
+		expected   string
+	}{
+		{
+			name:       "snake case and camel case",
+			template:   "{{.user_name}} {{.userName}}",
+			fieldInfos: []FieldInfo{{Name: "user_name"}, {Name: "userName"}},
+			expected:   `placeholders {{.user_name}} and {{.userName}} both generate field "UserName"`,
+		},
+		{
+			name:       "suffix notation and plain name",
+			template:   "{{.user:from}} {{.user_from}}",
+			fieldInfos: []FieldInfo{{Name: "user", Suffix: "from"}, {Name: "user_from"}},
+			expected:   `placeholders {{.user:from}} and {{.user_from}} both generate field "UserFrom"`,
+		},
+		{
+			name:       "renamed reserved name",
+			template:   "{{.localize}} {{.localize_field}}",
+			fieldInfos: []FieldInfo{{Name: "localize"}, {Name: "localize_field"}},
+			expected:   `placeholders {{.localize}} and {{.localize_field}} both generate field "LocalizeField"`,
+		},
+		{
+			name:       "setter of another field",
+			template:   "{{.name}} {{.with_name}}",
+			fieldInfos: []FieldInfo{{Name: "name"}, {Name: "with_name"}},
+			expected:   "the setter WithName of field Name collides with the field of the same name",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			messages := []MessageSource{{
+				ID:         "Collision",
+				Templates:  map[string]string{"ja": tt.template, "en": tt.template},
+				FieldInfos: tt.fieldInfos,
+			}}
+
+			_, err := Build(messages, nil, s.testConfig.Locales, s.testConfig)
+			s.Require().Error(err)
+			s.Contains(err.Error(), `message "Collision"`)
+			s.Contains(err.Error(), tt.expected)
+		})
+	}
+}
+
+func (s *ModelTestSuite) TestBuildSortsItemsByPrimaryLocale() {
+	placeholders := []PlaceholderSource{{
+		Kind: "fruit",
+		Items: map[string]map[string]string{
+			"apple":  {"en": "Apple", "ja": "りんご"},
+			"banana": {"en": "Banana", "ja": "ばなな"},
+		},
+	}}
+	cfg := *s.testConfig
+	cfg.Locales = []string{"en", "ja"}
+	cfg.PrimaryLocale = "ja"
+
+	defs, err := Build(nil, placeholders, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.Require().Len(defs.Placeholders, 1)
+
+	items := defs.Placeholders[0].Items
+	s.Require().Len(items, 2)
+	s.Equal("banana", items[0].ID) // "ばなな" sorts before "りんご"
+	s.Equal("apple", items[1].ID)
+}
+
+func (s *ModelTestSuite) TestBuildTypeNameCollision() {
+	placeholders := []PlaceholderSource{{
+		Kind:  "entity",
+		Items: map[string]map[string]string{"user": {"ja": "ユーザー", "en": "User"}},
+	}}
+
+	tests := []struct {
+		name      string
+		messageID string
+		fields    []FieldInfo
+		collision string
+	}{
+		{name: "text type", messageID: "entity_text", collision: "EntityText"},
+		{name: "text accessor ignoring case", messageID: "Entitytexts", collision: "EntityTexts"},
+		{name: "ID listing", messageID: "entity_ids", collision: "EntityIDs"},
+		{name: "value type", messageID: "UserIdValue", fields: []FieldInfo{{Name: "user_id"}}, collision: "UserIdValue"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			messages := []MessageSource{{
+				ID:         tt.messageID,
+				Templates:  map[string]string{"ja": "text", "en": "text"},
+				FieldInfos: tt.fields,
+			}}
+
+			_, err := Build(messages, placeholders, s.testConfig.Locales, s.testConfig)
+			s.Require().Error(err)
+			s.Contains(err.Error(), "collides with the placeholder type "+tt.collision)
+			s.Contains(err.Error(), "rename the message")
+		})
+	}
+
+	s.Run("message named after the kind", func() {
+		messages := []MessageSource{{ID: "Entity", Templates: map[string]string{"ja": "text", "en": "text"}}}
+		_, err := Build(messages, placeholders, s.testConfig.Locales, s.testConfig)
+		s.NoError(err, "Entity does not collide with EntityText")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildGeneratedNameCollision() {
+	tests := []struct {
+		name      string
+		messageID string
+		configure func(cfg *config.Config)
+		expected  string
+	}{
+		{name: "always generated", messageID: "TemplateFuncs", expected: "TemplateFuncs, which is always generated"},
+		{name: "placeholder kinds", messageID: "placeholder_kinds", expected: "PlaceholderKinds, which is always generated"},
+		{name: "message fields", messageID: "MessageFields", expected: "MessageFields, which is always generated"},
+		{name: "constructor", messageID: "LocalizableByID", configure: func(cfg *config.Config) { cfg.LocalizeByID = true },
+			expected: "NewLocalizableByID, which is generated for emit_localize_by_id"},
+		{name: "enabled option", messageID: "LocalizeByID", configure: func(cfg *config.Config) { cfg.LocalizeByID = true },
+			expected: "LocalizeByID, which is generated for emit_localize_by_id"},
+		{name: "variants", messageID: "UnknownVariantHook",
+			expected: "UnknownVariantHook, which is generated for variants"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			cfg := *s.testConfig
+			if tt.configure != nil {
+				tt.configure(&cfg)
+			}
+			messages := []MessageSource{{
+				ID:        tt.messageID,
+				Templates: map[string]string{"ja": "text", "en": "text"},
+				Variants:  []MessageVariant{{Name: "formal", Templates: map[string]string{"ja": "text", "en": "text"}}},
+			}}
+
+			_, err := Build(messages, nil, cfg.Locales, &cfg)
+			s.Require().Error(err)
+			s.Contains(err.Error(), fmt.Sprintf("message %q generates %s", tt.messageID, tt.expected))
+			s.Contains(err.Error(), "rename the message")
+		})
+	}
+
+	s.Run("disabled option", func() {
+		messages := []MessageSource{{ID: "LocalizeByID", Templates: map[string]string{"ja": "text", "en": "text"}}}
+		_, err := Build(messages, nil, s.testConfig.Locales, s.testConfig)
+		s.NoError(err, "LocalizeByID is only generated with emit_localize_by_id")
+	})
+
+	s.Run("enum placeholder", func() {
+		cfg := *s.testConfig
+		cfg.EnumPlaceholders = map[string][]string{"template_funcs": {"upper", "lower"}}
+		_, err := Build(nil, nil, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `placeholder "template_funcs" generates TemplateFuncs, which is always generated`)
+	})
+
+	s.Run("value placeholder", func() {
+		cfg := *s.testConfig
+		suffix := ""
+		cfg.ValueSuffix = &suffix
+		messages := []MessageSource{{
+			ID:         "Greeting",
+			Templates:  map[string]string{"ja": "{{.localizable}}", "en": "{{.localizable}}"},
+			FieldInfos: []FieldInfo{{Name: "localizable"}},
+		}}
+		_, err := Build(messages, nil, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "value placeholder {{.localizable}} generates Localizable, which is always generated")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildValueSuffix() {
+	placeholders := []PlaceholderSource{{
+		Kind:  "entity",
+		Items: map[string]map[string]string{"user": {"ja": "ユーザー", "en": "User"}},
+	}}
+	messages := []MessageSource{{
+		ID:         "EntityNotFound",
+		Templates:  map[string]string{"ja": "{{.entity}} {{.reason}}", "en": "{{.entity}} {{.reason}}"},
+		FieldInfos: []FieldInfo{{Name: "entity"}, {Name: "reason"}},
+	}}
+
+	for _, suffix := range []string{"Arg", ""} {
+		s.Run(suffix, func() {
+			cfg := *s.testConfig
+			cfg.ValueSuffix = &suffix
+			defs, err := Build(messages, placeholders, cfg.Locales, &cfg)
+			s.Require().NoError(err)
+			s.Equal("EntityText", defs.Messages[0].Fields[0].Type)
+			s.Equal("Reason"+suffix, defs.Messages[0].Fields[1].Type)
+		})
+	}
+
+	s.Run("value type named like a text type", func() {
+		cfg := *s.testConfig
+		suffix := ""
+		cfg.ValueSuffix = &suffix
+		collidingMessages := []MessageSource{{
+			ID:         "Labelled",
+			Templates:  map[string]string{"ja": "{{.entity_text}}", "en": "{{.entity_text}}"},
+			FieldInfos: []FieldInfo{{Name: "entity_text"}},
+		}}
+		_, err := Build(collidingMessages, placeholders, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `value placeholder {{.entity_text}} generates type EntityText, which collides with placeholder "entity"`)
+		s.Contains(err.Error(), "value_suffix")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildPlaceholderConsts() {
+	placeholders := []PlaceholderSource{{
+		Kind:  "entity",
+		Items: map[string]map[string]string{"user": {"ja": "ユーザー", "en": "User"}},
+	}}
+	cfg := *s.testConfig
+	cfg.PlaceholderConsts = true
+
+	defs, err := Build(nil, placeholders, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.Require().Len(defs.Placeholders, 1)
+	s.Equal("EntityID", defs.Placeholders[0].IDType)
+	s.Equal("EntityUser", defs.Placeholders[0].Items[0].ConstName)
+
+	s.Run("collides with a message", func() {
+		messages := []MessageSource{{ID: "EntityUser", Templates: map[string]string{"ja": "text", "en": "text"}}}
+		_, err := Build(messages, placeholders, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "collides with the placeholder type EntityUser")
+	})
+
+	s.Run("collides with another placeholder", func() {
+		colliding := []PlaceholderSource{
+			{Kind: "entity", Items: map[string]map[string]string{"user_admin": {"ja": "管理者", "en": "Admin"}}},
+			{Kind: "entity_user", Items: map[string]map[string]string{"admin": {"ja": "管理者", "en": "Admin"}}},
+		}
+		_, err := Build(nil, colliding, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "generates constant EntityUserAdmin")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildParamsType() {
+	messages := []MessageSource{
+		{ID: "Short", Templates: map[string]string{"ja": "{{.a}}", "en": "{{.a}}"}, FieldInfos: []FieldInfo{{Name: "a"}}},
+		{ID: "Long", Templates: map[string]string{"ja": "{{.a}} {{.b}}", "en": "{{.a}} {{.b}}"}, FieldInfos: []FieldInfo{{Name: "a"}, {Name: "b"}}},
+	}
+	cfg := *s.testConfig
+	cfg.ParamsMinFields = 2
+
+	defs, err := Build(messages, nil, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.Require().Len(defs.Messages, 2)
+	s.Equal("LongParams", defs.Messages[0].ParamsType)
+	s.Empty(defs.Messages[1].ParamsType, "messages below the threshold keep only the positional constructor")
+
+	s.Run("collides with another message", func() {
+		colliding := append(messages, MessageSource{ID: "LongParams", Templates: map[string]string{"ja": "text", "en": "text"}})
+		_, err := Build(colliding, nil, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "generates params type LongParams, which collides with LongParams")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildRuntimePlaceholders() {
+	cfg := *s.testConfig
+	cfg.RuntimePlaceholders = map[string]config.RuntimePlaceholder{
+		"appName": {Runtime: true},
+		"tenant":  {Runtime: false},
+	}
+	messages := []MessageSource{{
+		ID:         "Welcome",
+		Templates:  map[string]string{"ja": "{{.appName}} {{.tenant}}", "en": "{{.appName}} {{.tenant}}"},
+		FieldInfos: []FieldInfo{{Name: "appName"}, {Name: "tenant"}},
+	}}
+
+	defs, err := Build(messages, nil, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.Equal([]templatex.RuntimePlaceholder{{Name: "appName", Provider: "AppNameProvider"}}, defs.RuntimePlaceholders)
+	s.Require().Len(defs.Messages, 1)
+	s.Equal([]templatex.RuntimeField{{TemplateKey: "appName", Provider: "AppNameProvider"}}, defs.Messages[0].RuntimeFields)
+	s.Require().Len(defs.Messages[0].Fields, 1, "runtime: false keeps the placeholder a constructor argument")
+	s.Equal("Tenant", defs.Messages[0].Fields[0].FieldName)
+
+	s.Run("also defined in a placeholder file", func() {
+		placeholders := []PlaceholderSource{{
+			Kind:  "appName",
+			Items: map[string]map[string]string{"main": {"ja": "アプリ", "en": "App"}},
+		}}
+		_, err := Build(messages, placeholders, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `runtime placeholder "appName" is also defined in a placeholder file`)
+	})
+
+	s.Run("provider collides with a message", func() {
+		colliding := append(messages, MessageSource{ID: "AppNameProvider", Templates: map[string]string{"ja": "text", "en": "text"}})
+		_, err := Build(colliding, nil, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "generates variable AppNameProvider")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildEnumPlaceholders() {
+	cfg := *s.testConfig
+	cfg.EnumPlaceholders = map[string][]string{"status": {"pending", "in_review"}}
+	messages := []MessageSource{{
+		ID:         "OrderStatus",
+		Templates:  map[string]string{"ja": "{{.status}}", "en": "{{.status}}"},
+		FieldInfos: []FieldInfo{{Name: "status"}},
+	}}
+
+	defs, err := Build(messages, nil, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.Require().Len(defs.Placeholders, 1)
+	s.True(defs.Placeholders[0].IsEnum)
+	s.Equal("Status", defs.Placeholders[0].StructName)
+	s.Equal([]string{"StatusPending", "StatusInReview"}, itemConstNames(defs.Placeholders[0]))
+	s.Require().Len(defs.Messages[0].Fields, 1)
+	s.Equal("Status", defs.Messages[0].Fields[0].Type)
+
+	s.Run("also defined in a placeholder file", func() {
+		placeholders := []PlaceholderSource{{
+			Kind:  "status",
+			Items: map[string]map[string]string{"pending": {"ja": "保留", "en": "Pending"}},
+		}}
+		_, err := Build(messages, placeholders, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `enum placeholder "status" is also defined in a placeholder file`)
+	})
+
+	s.Run("invalid values", func() {
+		for want, values := range map[string][]string{
+			"must list at least one value":                    {},
+			"does not generate a valid Go identifier":         {"in-review"},
+			`values "in_review" and "inReview" both generate`: {"in_review", "inReview"},
+		} {
+			invalid := cfg
+			invalid.EnumPlaceholders = map[string][]string{"status": values}
+			_, err := Build(messages, nil, cfg.Locales, &invalid)
+			s.Require().Error(err)
+			s.Contains(err.Error(), want)
+		}
+	})
+
+	s.Run("value collides with a message", func() {
+		colliding := append(messages, MessageSource{ID: "StatusPending", Templates: map[string]string{"ja": "text", "en": "text"}})
+		_, err := Build(colliding, nil, cfg.Locales, &cfg)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "collides with the placeholder type StatusPending")
+	})
+}
+
+func (s *ModelTestSuite) TestBuildUnknownTemplateFunction() {
+	tests := []struct {
+		name     string
+		message  MessageSource
+		expected string
+	}{
+		{
+			name: "pipeline",
+			message: MessageSource{
+				ID:           "Welcome",
+				RawTemplates: map[string]interface{}{"ja": "ようこそ {{.name}}", "en": "Welcome {{.name | bogus}}"},
+				FieldInfos:   []FieldInfo{{Name: "name"}},
+				Location:     SourceLocation{File: "messages/welcome.yaml", Line: 3},
+			},
+			expected: `message "Welcome" (messages/welcome.yaml:3): locale "en" calls unknown template function "bogus"`,
+		},
+		{
+			name: "plural form with suffix notation",
+			message: MessageSource{
+				ID: "Moved",
+				RawTemplates: map[string]interface{}{"en": map[string]interface{}{
+					"one":   "{{.entity:from}} moved",
+					"other": "{{upper .entity:from}} moved",
+				}},
+				FieldInfos: []FieldInfo{{Name: "entity", Suffix: "from"}},
+			},
+			expected: `message "Moved": locale "en" calls unknown template function "upper"`,
+		},
+		{
+			name: "variant",
+			message: MessageSource{
+				ID:           "Hello",
+				RawTemplates: map[string]interface{}{"en": "Hello"},
+				Variants: []MessageVariant{{
+					Name:         "casual",
+					RawTemplates: map[string]interface{}{"en": "{{title \"hi\"}}"},
+				}},
+			},
+			expected: `message "Hello" variant "casual": locale "en" calls unknown template function "title"`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := Build([]MessageSource{tt.message}, nil, s.testConfig.Locales, s.testConfig)
+			s.Require().Error(err)
+			s.Contains(err.Error(), tt.expected)
+		})
+	}
+}
+
+func (s *ModelTestSuite) TestBuildUnknownSuffixReference() {
+	message := MessageSource{
+		ID: "Moved",
+		RawTemplates: map[string]interface{}{
+			"ja": "{{.entity:from}}から{{.entity:to}}へ",
+			"en": map[string]interface{}{
+				"one":   "{{.entity:from}} to {{.entity:unknown}}",
+				"other": "{{.entity:from}} to {{.entity:to}}",
+			},
+		},
+		FieldInfos: []FieldInfo{{Name: "entity", Suffix: "from"}, {Name: "entity", Suffix: "to"}},
+		Location:   SourceLocation{File: "messages/moved.yaml", Line: 1},
+	}
+
+	_, err := Build([]MessageSource{message}, nil, s.testConfig.Locales, s.testConfig)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `message "Moved" (messages/moved.yaml:1): locale "en" references {{.entity:unknown}}`)
+
+	message.RawTemplates["en"] = "{{.entity:from}} to {{.entity:to}}"
+	_, err = Build([]MessageSource{message}, nil, s.testConfig.Locales, s.testConfig)
+	s.NoError(err)
+}
+
+func (s *ModelTestSuite) TestBuildCustomTemplateFunctions() {
+	message := MessageSource{
+		ID:           "OrderTotal",
+		RawTemplates: map[string]interface{}{"en": "Total: {{money .amount}}"},
+		FieldInfos:   []FieldInfo{{Name: "amount"}},
+	}
+
+	_, err := Build([]MessageSource{message}, nil, s.testConfig.Locales, s.testConfig)
+	s.ErrorContains(err, `calls unknown template function "money"`)
+
+	cfg := *s.testConfig
+	cfg.TemplateFunctions = []string{"money"}
+	defs, err := Build([]MessageSource{message}, nil, cfg.Locales, &cfg)
+	s.Require().NoError(err)
+	s.True(defs.Messages[0].UsesFuncs)
+}
+
+func (s *ModelTestSuite) TestUnknownTemplateFunc() {
+	s.Equal("", unknownTemplateFunc(`{{.name | printf "%q"}} {{len .items}} {{locale "en" "s"}}`, "", "", nil))
+	s.Equal("bogus", unknownTemplateFunc("{{.name | bogus}}", "", "", nil))
+	s.Equal("", unknownTemplateFunc("{{.entity:from | printf \"%s\"}} {{.name | title | upper}}", "", "", nil))
+	s.Equal("upper", unknownTemplateFunc(`{{upper "x"}}`, "", "", nil), "pipeline functions only apply to fields")
+	s.Equal("", unknownTemplateFunc("{{money .amount}}", "", "", []string{"money"}))
+	s.Equal("money", unknownTemplateFunc("{{.amount | money}}", "", "", []string{"money"}), "field pipelines are removed, so custom functions cannot be piped")
+	s.Equal("bogus", unknownTemplateFunc("{{.entity:from | bogus}}", "", "", nil))
+	s.Equal("", unknownTemplateFunc("{{end}} {{bogus}}", "", "", nil), "other parse errors are left to go-i18n")
+	s.Equal("shout", unknownTemplateFunc("<<shout .name>> {{bogus}}", "<<", ">>", nil))
+}
+
+func (s *ModelTestSuite) TestUsesTemplateFuncs() {
+	s.True(usesTemplateFuncs(map[string]interface{}{"en": `file{{locale "en" "s"}}`}, nil, nil))
+	s.True(usesTemplateFuncs(map[string]interface{}{"en": map[string]interface{}{"other": `{{- locale "en" "s"}}`}}, nil, nil))
+	s.False(usesTemplateFuncs(map[string]interface{}{"en": "{{.locale}} selected"}, nil, nil))
+	s.False(usesTemplateFuncs(nil, nil, nil))
+
+	custom := []string{"money"}
+	s.True(usesTemplateFuncs(map[string]interface{}{"en": "Total: {{money .amount}}"}, nil, custom))
+	s.True(usesTemplateFuncs(map[string]interface{}{"en": `{{if .paid}}{{printf "%s" (money .amount)}}{{end}}`}, nil, custom))
+	s.True(usesTemplateFuncs(
+		map[string]interface{}{"en": "Total: <<money .amount>>"},
+		map[string]map[string]string{"en": {"leftDelim": "<<", "rightDelim": ">>"}}, custom))
+	s.False(usesTemplateFuncs(map[string]interface{}{"en": "Total: {{.amount}}"}, nil, custom))
+}
+
+func (s *ModelTestSuite) TestValidateTemplateFunctions() {
+	s.NoError(ValidateTemplateFunctions(nil))
+	s.NoError(ValidateTemplateFunctions([]string{"money", "shorten"}))
+	s.ErrorContains(ValidateTemplateFunctions([]string{"to-upper"}), `invalid template function "to-upper"`)
+	s.ErrorContains(ValidateTemplateFunctions([]string{"printf"}), "already defined")
+	s.ErrorContains(ValidateTemplateFunctions([]string{"locale"}), "already defined")
+	s.ErrorContains(ValidateTemplateFunctions([]string{"money", "money"}), "listed more than once")
+}
+
+func TestModelTestSuite(t *testing.T) {
+	suite.Run(t, new(ModelTestSuite))
+}
+
+func (s *ModelTestSuite) TestResolveItemRefs() {
+	placeholders := []PlaceholderSource{{
+		Kind:  "entity",
+		Items: map[string]map[string]string{"user": {"ja": "ユーザー", "en": "User"}},
+	}}
+	newMessages := func(template string, fields ...FieldInfo) []MessageSource {
+		return []MessageSource{{
+			ID:           "UserNotFound",
+			Templates:    map[string]string{"en": template},
+			RawTemplates: map[string]interface{}{"en": template},
+			FieldInfos:   fields,
+		}}
+	}
+
+	s.Run("rewrites references to fields", func() {
+		messages := newMessages(`{{entity "user"}} not found: {{.id}} {{ entity "user" }}`, FieldInfo{Name: "id"})
+		resolved, err := ResolveItemRefs(messages, placeholders)
+		s.Require().NoError(err)
+		s.Equal("{{.entity__user}} not found: {{.id}} {{.entity__user}}", resolved[0].Templates["en"])
+		s.Equal("{{.entity__user}} not found: {{.id}} {{.entity__user}}", resolved[0].RawTemplates["en"])
+		s.Equal([]ItemRef{{Kind: "entity", ID: "user"}}, resolved[0].ItemRefs)
+		// The source messages keep the templates as written
+		s.Equal(`{{entity "user"}} not found: {{.id}} {{ entity "user" }}`, messages[0].Templates["en"])
+	})
+
+	s.Run("leaves other calls alone", func() {
+		resolved, err := ResolveItemRefs(newMessages(`{{money "10"}}`), placeholders)
+		s.Require().NoError(err)
+		s.Equal(`{{money "10"}}`, resolved[0].Templates["en"])
+		s.Empty(resolved[0].ItemRefs)
+	})
+
+	s.Run("unknown item", func() {
+		_, err := ResolveItemRefs(newMessages(`{{entity "usr"}}`), placeholders)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `{{entity "usr"}} refers to unknown entity item "usr"`)
+	})
+
+	s.Run("collides with a placeholder", func() {
+		_, err := ResolveItemRefs(newMessages(`{{entity "user"}} {{.entity__user}}`, FieldInfo{Name: "entity__user"}), placeholders)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "collides with an item reference")
+	})
+}
@@ -3,15 +3,184 @@ package model
 
 import (
 	"fmt"
+	"go/token"
 	"regexp"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
 )
 
 // Pre-compiled regular expressions for better performance
 var (
 	templateFieldPattern       = regexp.MustCompile(`\{\{\s*\.\s*([a-zA-Z_][a-zA-Z0-9_]*)(\s*\|[^}]*)?\s*\}\}`)
-	templateFieldSuffixPattern = regexp.MustCompile(`\{\{\s*\.\s*([a-zA-Z_][a-zA-Z0-9_]*(?::[a-zA-Z0-9_]+)?)(\s*\|[^}]*)?\s*\}\}`)
+	templateFieldSuffixPattern = regexp.MustCompile(`\{\{\s*\.\s*([a-zA-Z_][^\s|}]*)(\s*\|[^}]*)?\s*\}\}`)
+	undefinedFuncPattern       = regexp.MustCompile(`function "([^"]+)" not defined`)
 )
 
+// messageTemplateFuncs are the functions the generated code registers for message templates,
+// on top of the text/template builtins and the configured template_functions; only their
+// names matter here
+var messageTemplateFuncs = texttemplate.FuncMap{
+	"locale": func(locale, text string, otherwise ...string) string { return "" },
+}
+
+// builtinTemplateFuncs are the functions predefined by text/template
+var builtinTemplateFuncs = []string{
+	"and", "call", "eq", "ge", "gt", "html", "index", "js", "le", "len", "lt",
+	"ne", "not", "or", "print", "printf", "println", "slice", "urlquery",
+}
+
+// fieldPipelineFuncs are the functions accepted after a field, as in {{.name | title}}.
+// processTemplateWithFieldInfos removes field pipelines from the generated templates.
+var fieldPipelineFuncs = texttemplate.FuncMap{
+	"title":      strings.ToTitle,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"capitalize": strings.ToTitle,
+	"camelCase":  strings.ToTitle,
+}
+
+// ValidateTemplateFunctions checks the custom function names configured with template_functions:
+// each must be a Go identifier and must not redefine a builtin or locale
+func ValidateTemplateFunctions(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("invalid template function %q: must be a Go identifier such as %q", name, "money")
+		}
+		if _, reserved := messageTemplateFuncs[name]; reserved || slices.Contains(builtinTemplateFuncs, name) {
+			return fmt.Errorf("invalid template function %q: it is already defined for message templates", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("template function %q is listed more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// templateFuncs returns messageTemplateFuncs together with the custom function names
+func templateFuncs(custom []string) texttemplate.FuncMap {
+	funcs := make(texttemplate.FuncMap, len(messageTemplateFuncs)+len(custom))
+	for name, fn := range messageTemplateFuncs {
+		funcs[name] = fn
+	}
+	for _, name := range custom {
+		funcs[name] = func(args ...interface{}) string { return "" }
+	}
+	return funcs
+}
+
+// unknownTemplateFunc returns the first function called in template that is not available
+// where it is called, or "" when there is none: field pipelines may use fieldPipelineFuncs,
+// other actions messageTemplateFuncs and the custom functions, and both the text/template
+// builtins. Field expressions are normalized first so suffix notation parses. Other parse
+// errors are left to go-i18n. Empty delimiters mean the defaults.
+func unknownTemplateFunc(template, leftDelim, rightDelim string, custom []string) string {
+	for _, match := range templateFieldSuffixPattern.FindAllStringSubmatch(template, -1) {
+		if match[2] == "" {
+			continue
+		}
+		if name := undefinedTemplateFunc("{{.field"+match[2]+"}}", "", "", fieldPipelineFuncs); name != "" {
+			return name
+		}
+	}
+
+	normalized := templateFieldSuffixPattern.ReplaceAllString(template, "{{.field}}")
+	return undefinedTemplateFunc(normalized, leftDelim, rightDelim, templateFuncs(custom))
+}
+
+// callsTemplateFuncs reports whether template calls locale or one of the custom functions
+// outside field pipelines, which are removed from the generated templates
+func callsTemplateFuncs(template, leftDelim, rightDelim string, custom []string) bool {
+	normalized := templateFieldSuffixPattern.ReplaceAllString(template, "{{.field}}")
+	funcs := templateFuncs(custom)
+	tmpl, err := texttemplate.New("").Delims(leftDelim, rightDelim).Funcs(funcs).Parse(normalized)
+	if err != nil || tmpl.Tree == nil {
+		return false
+	}
+	return callsFuncs(tmpl.Tree.Root, func(name string) bool {
+		_, ok := funcs[name]
+		return ok
+	})
+}
+
+// CallsTemplateFunctions reports whether template calls any function, whether piped from a field
+// as in {{.name | title}} or in an action as in {{money .amount}}
+func CallsTemplateFunctions(template string) bool {
+	for _, match := range templateFieldSuffixPattern.FindAllStringSubmatch(template, -1) {
+		if match[2] != "" {
+			return true
+		}
+	}
+
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	normalized := templateFieldSuffixPattern.ReplaceAllString(template, "{{.field}}")
+	if _, err := tree.Parse(normalized, "", "", map[string]*parse.Tree{}); err != nil || tree.Root == nil {
+		return false
+	}
+	return callsFuncs(tree.Root, func(string) bool { return true })
+}
+
+// callsFuncs reports whether the parse tree below node calls a function matched by isFunc
+func callsFuncs(node parse.Node, isFunc func(name string) bool) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if callsFuncs(child, isFunc) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return callsFuncs(n.Pipe, isFunc)
+	case *parse.IfNode:
+		return callsFuncs(n.Pipe, isFunc) || callsFuncs(n.List, isFunc) || callsFuncs(n.ElseList, isFunc)
+	case *parse.RangeNode:
+		return callsFuncs(n.Pipe, isFunc) || callsFuncs(n.List, isFunc) || callsFuncs(n.ElseList, isFunc)
+	case *parse.WithNode:
+		return callsFuncs(n.Pipe, isFunc) || callsFuncs(n.List, isFunc) || callsFuncs(n.ElseList, isFunc)
+	case *parse.TemplateNode:
+		return callsFuncs(n.Pipe, isFunc)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if callsFuncs(cmd, isFunc) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if callsFuncs(arg, isFunc) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return callsFuncs(n.Node, isFunc)
+	case *parse.IdentifierNode:
+		return isFunc(n.Ident)
+	}
+	return false
+}
+
+// undefinedTemplateFunc parses template with funcs and returns the function reported as not defined, if any
+func undefinedTemplateFunc(template, leftDelim, rightDelim string, funcs texttemplate.FuncMap) string {
+	_, err := texttemplate.New("").Delims(leftDelim, rightDelim).Funcs(funcs).Parse(template)
+	if err == nil {
+		return ""
+	}
+	if match := undefinedFuncPattern.FindStringSubmatch(err.Error()); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
 // processTemplateForDuplicates converts template strings to use numbered placeholders for duplicates
 // Example: "{{.name}} hello, {{.name}} world" -> "{{.name1}} hello, {{.name2}} world"
 func processTemplateForDuplicates(template string, fields []string) string {
@@ -72,6 +241,8 @@ func ProcessMessageTemplatesWithFieldInfos(templates map[string]string, fieldInf
 
 // processTemplateWithFieldInfos converts template strings to use suffix-based placeholders
 // Example: "{{.entity:from}} to {{.entity:to}}" -> "{{.entityFrom}} to {{.entityTo}}"
+// Field expressions are matched loosely so any configured suffix separator is captured,
+// and only rewritten when they equal the String() of one of fieldInfos
 func processTemplateWithFieldInfos(template string, fieldInfos []FieldInfo) string {
 	result := template
 
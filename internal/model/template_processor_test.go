@@ -379,6 +379,19 @@ func (s *TemplateProcessorTestSuite) TestGenerateStructName() {
 	}
 }
 
+func (s *TemplateProcessorTestSuite) TestProcessTemplateWithCustomSeparator() {
+	fieldInfos := []FieldInfo{
+		{Name: "entity", Suffix: "from", Separator: "__"},
+		{Name: "entity", Suffix: "to", Separator: "__"},
+	}
+
+	result := processTemplateWithFieldInfos("{{.entity__from}} to {{.entity__to | upper}}", fieldInfos)
+	s.Equal("{{.entityFrom}} to {{.entityTo}}", result)
+
+	// Colon notation is left as is when another separator is configured
+	s.Equal("{{.entity:from}}", processTemplateWithFieldInfos("{{.entity:from}}", fieldInfos))
+}
+
 func (s *TemplateProcessorTestSuite) TestFieldInfoString() {
 	tests := []struct {
 		name     string
@@ -388,6 +401,7 @@ func (s *TemplateProcessorTestSuite) TestFieldInfoString() {
 		{"field without suffix", FieldInfo{Name: "name", Suffix: ""}, "name"},
 		{"field with suffix", FieldInfo{Name: "name", Suffix: "user"}, "name:user"},
 		{"field with numeric suffix", FieldInfo{Name: "value", Suffix: "1"}, "value:1"},
+		{"field with custom separator", FieldInfo{Name: "entity", Suffix: "from", Separator: "__"}, "entity__from"},
 	}
 
 	for _, tt := range tests {
@@ -466,6 +480,56 @@ func (s *TemplateProcessorTestSuite) TestBuildWithEmptyLocales() {
 	s.Equal("TestMessage", result.Messages[0].ID)
 }
 
+func (s *TemplateProcessorTestSuite) TestBuildSortModes() {
+	messages := []MessageSource{
+		{ID: "Zebra", Templates: map[string]string{"en": "zebra"}, Position: 0},
+		{ID: "Apple", Templates: map[string]string{"en": "apple"}, Position: 1},
+	}
+	placeholders := []PlaceholderSource{
+		{
+			Kind: "status",
+			Items: map[string]map[string]string{
+				"pending": {"en": "Pending"},
+				"done":    {"en": "Done"},
+			},
+			Position:  0,
+			ItemOrder: []string{"pending", "done"},
+		},
+		{
+			Kind:      "entity",
+			Items:     map[string]map[string]string{"user": {"en": "User"}},
+			Position:  1,
+			ItemOrder: []string{"user"},
+		},
+	}
+
+	s.Run("alpha sorts by ID and localized text", func() {
+		result, err := Build(messages, placeholders, []string{"en"}, s.testConfig)
+		s.Require().NoError(err)
+
+		s.Equal("Apple", result.Messages[0].ID)
+		s.Equal("Zebra", result.Messages[1].ID)
+		s.Equal("EntityText", result.Placeholders[0].StructName)
+		s.Equal("StatusText", result.Placeholders[1].StructName)
+		s.Equal("done", result.Placeholders[1].Items[0].ID)
+	})
+
+	s.Run("source preserves source positions", func() {
+		cfg := *s.testConfig
+		cfg.Sort = config.SortSource
+
+		result, err := Build(messages, placeholders, []string{"en"}, &cfg)
+		s.Require().NoError(err)
+
+		s.Equal("Zebra", result.Messages[0].ID)
+		s.Equal("Apple", result.Messages[1].ID)
+		s.Equal("StatusText", result.Placeholders[0].StructName)
+		s.Equal("EntityText", result.Placeholders[1].StructName)
+		s.Equal("pending", result.Placeholders[0].Items[0].ID)
+		s.Equal("done", result.Placeholders[0].Items[1].ID)
+	})
+}
+
 // TestSuite runner
 func TestTemplateProcessorTestSuite(t *testing.T) {
 	suite.Run(t, new(TemplateProcessorTestSuite))
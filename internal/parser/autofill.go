@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+// AutofillMessages copies the source locale text of every message into the configured locales
+// it has no translation for, recording the copies in Autofilled so generated code can flag them.
+// It returns a description of each copied translation.
+func AutofillMessages(messages []model.MessageSource, source string, locales []string) []string {
+	var filled []string
+	for i := range messages {
+		msg := &messages[i]
+		template, ok := msg.Templates[source]
+		if !ok {
+			continue
+		}
+		for _, locale := range locales {
+			if _, exists := msg.Templates[locale]; exists {
+				continue
+			}
+			if msg.Autofilled == nil {
+				msg.Autofilled = make(map[string]string)
+			}
+			msg.Templates[locale] = template
+			if raw, ok := msg.RawTemplates[source]; ok {
+				msg.RawTemplates[locale] = raw
+			}
+			if metadata, ok := msg.Metadata[source]; ok {
+				msg.Metadata[locale] = metadata
+			}
+			msg.Autofilled[locale] = source
+			filled = append(filled, fmt.Sprintf("message %q (locale: %s) in %s", msg.ID, locale, msg.Location))
+		}
+	}
+	return filled
+}
+
+// AutofillPlaceholders copies the source locale text of every text placeholder item into the
+// configured locales it has no translation for, like AutofillMessages.
+// Value placeholders have no localized text and are left untouched.
+func AutofillPlaceholders(placeholders []model.PlaceholderSource, source string, locales []string) []string {
+	var filled []string
+	for i := range placeholders {
+		ph := &placeholders[i]
+
+		ids := make([]string, 0, len(ph.Items))
+		for id := range ph.Items {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			text, ok := ph.Items[id][source]
+			if !ok {
+				continue
+			}
+			for _, locale := range locales {
+				if _, exists := ph.Items[id][locale]; exists {
+					continue
+				}
+				if ph.Autofilled == nil {
+					ph.Autofilled = make(map[string]map[string]string)
+				}
+				if ph.Autofilled[id] == nil {
+					ph.Autofilled[id] = make(map[string]string)
+				}
+				ph.Items[id][locale] = text
+				if forms, ok := ph.PluralItems[id][source]; ok {
+					ph.PluralItems[id][locale] = forms
+				}
+				ph.Autofilled[id][locale] = source
+				filled = append(filled, fmt.Sprintf("placeholder %s.%s (locale: %s)", ph.Kind, id, locale))
+			}
+		}
+	}
+	return filled
+}
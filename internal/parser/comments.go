@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sourceComments extracts translator-facing comments from a YAML file: the comment block at
+// the top of the file (separated from the first key by a blank line) and the comments
+// attached to each top-level key. JSON has no comments, so it yields nothing.
+func sourceComments(content []byte, ext string) (fileComment string, keyComments map[string]string) {
+	keyComments = map[string]string{}
+	if ext == jsonExt {
+		return "", keyComments
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return "", keyComments
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return "", keyComments
+	}
+
+	fileComment = cleanComment(doc.HeadComment)
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return fileComment, keyComments
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		var parts []string
+		for _, comment := range []string{key.HeadComment, key.LineComment} {
+			if cleaned := cleanComment(comment); cleaned != "" {
+				parts = append(parts, cleaned)
+			}
+		}
+		if len(parts) > 0 {
+			keyComments[key.Value] = strings.Join(parts, "\n")
+		}
+	}
+	return fileComment, keyComments
+}
+
+// cleanComment strips YAML comment markers, returning the comment text line by line
+func cleanComment(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "#")
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -4,26 +4,85 @@ package parser
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"unicode"
 
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
 	"github.com/hacomono-lib/go-i18ngen/internal/model"
+	"github.com/hacomono-lib/go-i18ngen/internal/utils"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	jsonExt = ".json"
+
+	// DefaultLocale is the pseudo-locale assigned to templates from simple-format message files
+	DefaultLocale = "default"
+
+	// MetadataKey holds per-locale go-i18n hints of a message instead of a translation
+	MetadataKey = "metadata"
+
+	// MarkdownKey flags a message whose text is rendered from markdown to HTML
+	MarkdownKey = "markdown"
+
+	// TagsKey holds the labels used to select messages with the tags option
+	TagsKey = "tags"
+
+	// NoPluralKey lists the locales of a plural message that use a single text for every count
+	NoPluralKey = "no_plural"
+
+	// BackendKey overrides the backend option for a message: go-i18n or filesystem
+	BackendKey = "backend"
+
+	// CountHintKey describes the expected magnitude of the count of a plural message for translators
+	CountHintKey = "count_hint"
+
+	// ContextKey holds translator context of a message: a note, or a note and a screenshot reference
+	ContextKey = "context"
 )
 
+// metadataKeys are the go-i18n message fields a metadata block may set
+var metadataKeys = []string{"description", "hash", "leftDelim", "rightDelim"}
+
 // Pre-compiled regular expressions for better performance
 var (
-	fieldPattern = regexp.MustCompile(`\{\{\s*\.\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+	fieldPattern         = regexp.MustCompile(`\{\{\s*\.\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+	quotedStringPattern  = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`")
+	fieldArgumentPattern = regexp.MustCompile(`(?:^|[\s(])(\$?)\.([a-zA-Z_][a-zA-Z0-9_]*)`)
 )
 
-func ParseMessages(pattern string) ([]model.MessageSource, error) {
+// templateKeywords start actions that are not function calls
+var templateKeywords = map[string]bool{
+	"if": true, "else": true, "end": true, "range": true, "with": true, "template": true,
+	"define": true, "block": true, "break": true, "continue": true,
+}
+
+// controlKeywords start actions whose pipeline may test fields, as in {{if .reason}}
+var controlKeywords = map[string]bool{"if": true, "else": true, "range": true, "with": true}
+
+// ParseMessages parses the message files matching pattern.
+// suffixSeparator splits suffix notation such as {{.entity:from}}; empty selects the default ":".
+// Fields are extracted from the template of the first entry of locales a message defines,
+// falling back to the first locale in sorted order, so pass the primary locale first.
+func ParseMessages(pattern, suffixSeparator string, locales []string) ([]model.MessageSource, error) {
+	return ParseMessagesParallel(pattern, suffixSeparator, locales, 1, "")
+}
+
+// ParseMessagesParallel parses the message files matching pattern like ParseMessages, reading and
+// decoding up to maxParallel files at a time; 0 selects the number of CPUs. The messages of every
+// file are kept for the result, so the limit bounds concurrency, not the memory of the corpus.
+// Messages and errors are reported in file order whatever the limit. When inputFormat ("yaml" or
+// "json") is set, every file is decoded in that format regardless of its extension.
+func ParseMessagesParallel(pattern, suffixSeparator string, locales []string, maxParallel int, inputFormat string) ([]model.MessageSource, error) {
 	files, err := filepath.Glob(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid glob pattern for messages %q: %w", pattern, err)
@@ -32,60 +91,474 @@ func ParseMessages(pattern string) ([]model.MessageSource, error) {
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no message files found matching pattern %q", pattern)
 	}
+	if maxParallel <= 0 {
+		maxParallel = runtime.NumCPU()
+	}
+
+	perFile := make([][]model.MessageSource, len(files))
+	errs := make([]error, len(files))
+	slots := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, file string) {
+			defer func() { <-slots; wg.Done() }()
+			perFile[i], errs[i] = parseMessageFile(file, suffixSeparator, locales, inputFormat)
+		}(i, file)
+	}
+	wg.Wait()
 
 	var results []model.MessageSource
-	for _, file := range files {
-		f, err := os.Open(file) // #nosec G304 - Opening message files is intentional
-		if err != nil {
-			return nil, fmt.Errorf("failed to open message file %q: %w", file, err)
+	for i := range files {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		// Positions count messages across files, so shift those counted within the file
+		offset := len(results)
+		for _, msg := range perFile[i] {
+			msg.Position += offset
+			results = append(results, msg)
 		}
-		defer func() { _ = f.Close() }()
+	}
+	return results, nil
+}
+
+// parseMessageFile reads and parses the messages of one file
+func parseMessageFile(file, suffixSeparator string, locales []string, inputFormat string) ([]model.MessageSource, error) {
+	content, err := os.ReadFile(file) // #nosec G304 - Reading message files is intentional
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message file %q: %w", file, err)
+	}
+	return appendMessages(nil, content, file, formatExt(file, inputFormat), suffixSeparator, locales)
+}
+
+// formatExt returns the extension selecting the decoder of file: that of inputFormat when it is
+// set, otherwise the file's own. Files not ending in .json are decoded as YAML.
+func formatExt(file, inputFormat string) string {
+	if inputFormat != "" {
+		return "." + inputFormat
+	}
+	return filepath.Ext(file)
+}
 
-		ext := filepath.Ext(file)
-		data, err := decodeMessageFileWithRaw(f, ext)
+// ParseMessagesReader parses a single message document read from r, such as standard input.
+// The name identifies the document in errors and source locations; a ".json" extension selects
+// JSON decoding, anything else is decoded as YAML. locales orders field extraction as in ParseMessages.
+func ParseMessagesReader(r io.Reader, name, suffixSeparator string, locales []string) ([]model.MessageSource, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read messages from %s: %w", name, err)
+	}
+	ext := filepath.Ext(name)
+	if ext != jsonExt {
+		ext = ".yaml"
+	}
+	return appendMessages(nil, content, name, ext, suffixSeparator, locales)
+}
+
+// appendMessages decodes and validates the messages of one file and appends them to results
+func appendMessages(
+	results []model.MessageSource,
+	content []byte,
+	file, ext, suffixSeparator string,
+	locales []string,
+) ([]model.MessageSource, error) {
+	data, err := decodeMessageFileWithRaw(content, ext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode message file %q (ext: %s): %w", file, ext, err)
+	}
+
+	for _, id := range orderedIDs(data.Templates, data.Order) {
+		localeTemplates := data.Templates[id]
+		// Validate all locales for duplicate placeholders, complexity, and safety
+		for locale, template := range localeTemplates {
+			if err := validateNoDuplicatePlaceholders(template, suffixSeparator); err != nil {
+				return nil, fmt.Errorf("validation error in message %q (locale: %s) in file %q: %w", id, locale, file, err)
+			}
+			if err := validateTemplateComplexity(template); err != nil {
+				return nil, fmt.Errorf("complexity validation error in message %q (locale: %s) in file %q: %w", id, locale, file, err)
+			}
+		}
+		if err := validateSuffixKeys(localeTemplates, suffixSeparator); err != nil {
+			return nil, fmt.Errorf("validation error in message %q in file %q: %w", id, file, err)
+		}
+
+		// Fields, and thus constructor arguments, follow the template of the preferred locale,
+		// so the generated signature does not depend on map iteration or source key order
+		fieldInfos := ExtractFieldInfos(preferredTemplate(localeTemplates, locales), suffixSeparator)
+
+		// Get raw templates for this message ID
+		rawTemplates := data.RawTemplates[id]
+		if rawTemplates == nil {
+			rawTemplates = make(map[string]interface{})
+		}
+		for locale, raw := range rawTemplates {
+			if err := validatePluralForms(raw); err != nil {
+				return nil, fmt.Errorf("validation error in message %q (locale: %s) in file %q: %w", id, locale, file, err)
+			}
+		}
+
+		for _, locale := range data.NoPlural[id] {
+			if _, isPlural := rawTemplates[locale].(map[string]interface{}); isPlural {
+				return nil, fmt.Errorf("validation error in message %q in file %q: locale %s is listed in %s but has plural forms",
+					id, file, locale, NoPluralKey)
+			}
+		}
+
+		metadata := data.Metadata[id]
+		for locale := range metadata {
+			if _, exists := localeTemplates[locale]; !exists {
+				return nil, fmt.Errorf("validation error in message %q in file %q: %s for locale %s has no template", id, file, MetadataKey, locale)
+			}
+		}
+
+		variants, err := buildVariants(id, data.Variants[id], localeTemplates, suffixSeparator)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode message file %q (ext: %s): %w", file, ext, err)
+			return nil, fmt.Errorf("validation error in message %q in file %q: %w", id, file, err)
 		}
 
-		for id, localeTemplates := range data.Templates {
-			// Validate all locales for duplicate placeholders, complexity, and safety
-			for locale, template := range localeTemplates {
-				if err := validateNoDuplicatePlaceholders(template); err != nil {
-					return nil, fmt.Errorf("validation error in message %q (locale: %s) in file %q: %w", id, locale, file, err)
-				}
-				if err := validateTemplateComplexity(template); err != nil {
-					return nil, fmt.Errorf("complexity validation error in message %q (locale: %s) in file %q: %w", id, locale, file, err)
+		results = append(results, model.MessageSource{
+			ID:           id,
+			Templates:    localeTemplates,
+			RawTemplates: rawTemplates,
+			FieldInfos:   fieldInfos,
+			Position:     len(results),
+			Location:     model.SourceLocation{File: file, Line: data.Lines[id]},
+			Description:  data.Comments[id],
+			Metadata:     metadata,
+			Variants:     variants,
+			Markdown:     data.Markdown[id],
+			Context:      data.Context[id],
+			Tags:         data.Tags[id],
+			NoPlural:     data.NoPlural[id],
+			Backend:      data.Backend[id],
+			CountHint:    data.CountHint[id],
+		})
+	}
+	return results, nil
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// preferredTemplate returns the template of the first locale in locales that has one,
+// falling back to the first locale in sorted order so the choice never depends on map iteration
+func preferredTemplate(templates map[string]string, locales []string) string {
+	for _, locale := range locales {
+		if template, ok := templates[locale]; ok {
+			return template
+		}
+	}
+	if keys := sortedKeys(templates); len(keys) > 0 {
+		return templates[keys[0]]
+	}
+	return ""
+}
+
+// ResolveDefaultLocale assigns templates of the "default" pseudo-locale (produced by
+// simple-format message files) to the primary locale, so they are rendered like any
+// other configured locale instead of becoming orphaned data. A message with templates for both
+// "default" and the primary locale is ambiguous and rejected.
+func ResolveDefaultLocale(messages []model.MessageSource, primaryLocale string) ([]model.MessageSource, error) {
+	for i := range messages {
+		msg := &messages[i]
+		template, ok := msg.Templates[DefaultLocale]
+		if !ok {
+			continue
+		}
+		if _, ok := msg.Templates[primaryLocale]; ok {
+			return nil, fmt.Errorf("message %q in %s has templates for both %q and the primary locale %q",
+				msg.ID, msg.Location, DefaultLocale, primaryLocale)
+		}
+
+		templates := make(map[string]string, len(msg.Templates))
+		for locale, t := range msg.Templates {
+			templates[locale] = t
+		}
+		delete(templates, DefaultLocale)
+		templates[primaryLocale] = template
+		msg.Templates = templates
+
+		if raw, ok := msg.RawTemplates[DefaultLocale]; ok {
+			rawTemplates := make(map[string]interface{}, len(msg.RawTemplates))
+			for locale, t := range msg.RawTemplates {
+				rawTemplates[locale] = t
+			}
+			delete(rawTemplates, DefaultLocale)
+			rawTemplates[primaryLocale] = raw
+			msg.RawTemplates = rawTemplates
+		}
+	}
+	return messages, nil
+}
+
+// ValidateMessageLocales ensures every message has a template in at least one configured locale.
+// A message defined only in other locales would have nothing to render, so it is rejected
+// together with the unexpected locales that were found.
+func ValidateMessageLocales(messages []model.MessageSource, locales []string) error {
+	for _, msg := range messages {
+		var unexpected []string
+		for locale := range msg.Templates {
+			if slices.Contains(locales, locale) {
+				unexpected = nil
+				break
+			}
+			unexpected = append(unexpected, locale)
+		}
+		if len(unexpected) == 0 {
+			continue
+		}
+		sort.Strings(unexpected)
+		return fmt.Errorf("message %q in %s has no template in configured locales %v (found: %v)",
+			msg.ID, msg.Location, locales, unexpected)
+	}
+	return nil
+}
+
+// FindUnconfiguredLocales describes every template in a locale missing from locales, sorted by
+// message and locale. Such templates are never rendered, which usually means a typo in the locale
+// key or a locale left out of the configuration. Simple-format templates have no locale and are skipped.
+func FindUnconfiguredLocales(messages []model.MessageSource, locales []string) []string {
+	var unconfigured []string
+	for _, msg := range messages {
+		for _, locale := range sortedKeys(msg.Templates) {
+			if locale == DefaultLocale || slices.Contains(locales, locale) {
+				continue
+			}
+			unconfigured = append(unconfigured, fmt.Sprintf("message %q (locale: %s) in %s", msg.ID, locale, msg.Location))
+		}
+	}
+	sort.Strings(unconfigured)
+	return unconfigured
+}
+
+// FilterByTags returns the messages carrying at least one of tags, in their original order.
+// All messages are returned when tags is empty.
+func FilterByTags(messages []model.MessageSource, tags []string) []model.MessageSource {
+	if len(tags) == 0 {
+		return messages
+	}
+	var filtered []model.MessageSource
+	for _, msg := range messages {
+		for _, tag := range msg.Tags {
+			if slices.Contains(tags, tag) {
+				filtered = append(filtered, msg)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// KeepLocales drops the templates of messages in locales other than locales, keeping the
+// "default" key for ResolveDefaultLocale. The messages are modified in place and returned.
+func KeepLocales(messages []model.MessageSource, locales []string) []model.MessageSource {
+	keep := func(locale string) bool {
+		return locale == DefaultLocale || slices.Contains(locales, locale)
+	}
+	for i := range messages {
+		msg := &messages[i]
+		deleteLocales(msg.Templates, keep)
+		deleteLocales(msg.RawTemplates, keep)
+		deleteLocales(msg.Autofilled, keep)
+		deleteLocales(msg.Metadata, keep)
+		msg.NoPlural = slices.DeleteFunc(msg.NoPlural, func(locale string) bool { return !keep(locale) })
+		for j := range msg.Variants {
+			deleteLocales(msg.Variants[j].Templates, keep)
+			deleteLocales(msg.Variants[j].RawTemplates, keep)
+		}
+	}
+	return messages
+}
+
+// deleteLocales removes the entries of m whose locale is not kept
+func deleteLocales[V any](m map[string]V, keep func(locale string) bool) {
+	for locale := range m {
+		if !keep(locale) {
+			delete(m, locale)
+		}
+	}
+}
+
+// FindMissingPrimaryLocale describes every message without a template in primaryLocale, sorted by
+// message. Such messages fall back to an arbitrary other locale when a translation is missing.
+func FindMissingPrimaryLocale(messages []model.MessageSource, primaryLocale string) []string {
+	var missing []string
+	for _, msg := range messages {
+		if _, ok := msg.Templates[primaryLocale]; ok {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("message %q in %s", msg.ID, msg.Location))
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// FindMissingPluralForms describes every message that has plural forms in some locale but a plain
+// string in others, sorted by message, listing those locales. The plain string is rendered for
+// every count there, so the message silently stops pluralizing. Locales whose CLDR rules have a single
+// form, such as ja, those listed in nonPlural and those in the no_plural list of the message are
+// complete without plural forms.
+func FindMissingPluralForms(messages []model.MessageSource, nonPlural []string) []string {
+	var missing []string
+	for _, msg := range messages {
+		plural := false
+		var lacking []string
+		for _, locale := range sortedRawKeys(msg.RawTemplates) {
+			switch msg.RawTemplates[locale].(type) {
+			case map[string]interface{}, map[string]string:
+				plural = true
+			case string:
+				if locale != DefaultLocale && !slices.Contains(nonPlural, locale) && !slices.Contains(msg.NoPlural, locale) &&
+					utils.HasPluralForms(locale) {
+					lacking = append(lacking, locale)
 				}
 			}
+		}
+		if plural && len(lacking) > 0 {
+			missing = append(missing, fmt.Sprintf("message %q in %s (locales without plural forms: %s)",
+				msg.ID, msg.Location, strings.Join(lacking, ", ")))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
 
-			// Use primary locale (first available) to extract fields
-			var primaryTemplate string
-			for _, template := range localeTemplates {
-				primaryTemplate = template
+// FindMissingCountHints describes every message with plural forms in some locale but no count_hint,
+// sorted by message. The hint is informational, telling translators the expected magnitude of the
+// count (e.g. "usually 1-100") to choose wording for.
+func FindMissingCountHints(messages []model.MessageSource) []string {
+	var missing []string
+	for _, msg := range messages {
+		if msg.CountHint != "" {
+			continue
+		}
+		for _, raw := range msg.RawTemplates {
+			if _, ok := raw.(map[string]interface{}); ok {
+				missing = append(missing, fmt.Sprintf("message %q in %s", msg.ID, msg.Location))
 				break
 			}
-			fieldInfos := extractFieldInfos(primaryTemplate)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
 
-			// Get raw templates for this message ID
-			rawTemplates := data.RawTemplates[id]
-			if rawTemplates == nil {
-				rawTemplates = make(map[string]interface{})
+// sortedRawKeys returns the locales of raw templates in sorted order
+func sortedRawKeys(raw map[string]interface{}) []string {
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FindEmptyTemplates describes every blank template, including blank plural forms, sorted by
+// message and locale. An empty translation renders as an empty string, which is almost always a mistake.
+func FindEmptyTemplates(messages []model.MessageSource) []string {
+	var empty []string
+	for _, msg := range messages {
+		for _, locale := range sortedRawKeys(msg.RawTemplates) {
+			var forms map[string]interface{}
+			switch raw := msg.RawTemplates[locale].(type) {
+			case map[string]interface{}:
+				forms = raw
+			case map[string]string:
+				forms = make(map[string]interface{}, len(raw))
+				for form, text := range raw {
+					forms[form] = text
+				}
+			default:
+				if text, _ := raw.(string); strings.TrimSpace(text) == "" {
+					empty = append(empty, fmt.Sprintf("message %q (locale: %s) in %s", msg.ID, locale, msg.Location))
+				}
+				continue
+			}
+			for _, form := range utils.PluralCategories {
+				text, exists := forms[form]
+				if !exists {
+					continue
+				}
+				if text, _ := text.(string); strings.TrimSpace(text) == "" {
+					empty = append(empty, fmt.Sprintf("message %q (locale: %s, form: %s) in %s", msg.ID, locale, form, msg.Location))
+				}
 			}
+		}
+	}
+	sort.Strings(empty)
+	return empty
+}
+
+// validatePluralForms ensures the keys of a plural map are CLDR plural categories,
+// so typos such as "ohter" are reported instead of being silently ignored
+func validatePluralForms(raw interface{}) error {
+	forms, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil
+	}
 
-			results = append(results, model.MessageSource{
-				ID:           id,
-				Templates:    localeTemplates,
-				RawTemplates: rawTemplates,
-				FieldInfos:   fieldInfos,
-			})
+	keys := make([]string, 0, len(forms))
+	for form := range forms {
+		keys = append(keys, form)
+	}
+	sort.Strings(keys)
+	for _, form := range keys {
+		if !utils.IsPluralCategory(form) {
+			return fmt.Errorf("invalid plural form %q: must be one of %s", form, strings.Join(utils.PluralCategories, ", "))
 		}
 	}
-	return results, nil
+	return nil
+}
+
+// validateSuffixKeys rejects templates that already use the resolved key of a suffixed field
+// (e.g. {{.entityFrom}}) while another template uses suffix notation ({{.entity:from}}) for it.
+// Suffix notation is rewritten to the resolved key during generation, so mixing both forms
+// across locales would silently bind two spellings to the same field.
+func validateSuffixKeys(templates map[string]string, suffixSeparator string) error {
+	locales := make([]string, 0, len(templates))
+	for locale := range templates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	resolved := map[string]string{} // resolved key -> suffix notation
+	for _, locale := range locales {
+		for _, info := range ExtractFieldInfos(templates[locale], suffixSeparator) {
+			if info.Suffix != "" {
+				resolved[info.GenerateTemplateKey()] = info.String()
+			}
+		}
+	}
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	for _, locale := range locales {
+		for _, info := range ExtractFieldInfos(templates[locale], suffixSeparator) {
+			notation, ok := resolved[info.Name]
+			if info.Suffix != "" || !ok {
+				continue
+			}
+			return fmt.Errorf(
+				"locale %s uses {{.%s}} while suffix notation {{.%s}} resolves to the same key: "+
+					"use {{.%s}} in every locale", locale, info.Name, notation, notation)
+		}
+	}
+	return nil
 }
 
 // validateNoDuplicatePlaceholders checks for duplicate placeholders without suffixes
-func validateNoDuplicatePlaceholders(template string) error {
-	fieldInfos := extractFieldInfos(template)
+func validateNoDuplicatePlaceholders(template, suffixSeparator string) error {
+	fieldInfos := ExtractFieldInfos(template, suffixSeparator)
 	fieldCounts := make(map[string]int)
 
 	for _, info := range fieldInfos {
@@ -99,8 +572,8 @@ func validateNoDuplicatePlaceholders(template string) error {
 		if count > 1 {
 			return fmt.Errorf(
 				"duplicate placeholder %q found (%d times) - use suffix notation "+
-					"to distinguish multiple instances (e.g., {{.%s:from}} and {{.%s:to}})",
-				fieldName, count, fieldName, fieldName)
+					"to distinguish multiple instances (e.g., {{.%s%sfrom}} and {{.%s%sto}})",
+				fieldName, count, fieldName, separatorOrDefault(suffixSeparator), fieldName, separatorOrDefault(suffixSeparator))
 		}
 	}
 
@@ -136,41 +609,54 @@ func validateTemplateComplexity(tmpl string) error {
 		return fmt.Errorf("template is too complex: %d placeholders exceed maximum 20", placeholderCount)
 	}
 
-	// Check for potential circular references using simple pattern detection
-	if err := validateNoCircularPatterns(tmpl); err != nil {
-		return err
-	}
-
 	return nil
 }
 
-// validateNoCircularPatterns checks for potential circular reference patterns
-func validateNoCircularPatterns(tmpl string) error {
-	// Extract all field references and check for duplicates without suffixes
-	matches := fieldPattern.FindAllStringSubmatch(tmpl, -1)
-
-	fieldCounts := make(map[string]int)
-	for _, match := range matches {
-		if len(match) > 1 {
-			fieldName := match[1]
-			fieldCounts[fieldName]++
-		}
+// ValidateSuffixSeparator checks that a configured suffix separator cannot be mistaken for part of
+// a placeholder name or break the template syntax around it
+func ValidateSuffixSeparator(separator string) error {
+	if separator == "" {
+		return nil
 	}
-
-	// Check for potential circular references (same field used multiple times without suffix notation)
-	for _, count := range fieldCounts {
-		if count > 1 {
-			// This might be a circular reference, but it's handled by duplicate validation
-			// Just log a warning for now
+	if separator == "_" {
+		return fmt.Errorf("invalid suffix separator %q: a single underscore is part of snake_case placeholder names", separator)
+	}
+	for _, r := range separator {
+		if r == '_' {
 			continue
 		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return fmt.Errorf("invalid suffix separator %q: letters and digits are part of placeholder names", separator)
+		}
+		if unicode.IsSpace(r) || strings.ContainsRune(".|{}", r) {
+			return fmt.Errorf("invalid suffix separator %q: %q is part of the template syntax", separator, r)
+		}
 	}
-
 	return nil
 }
 
-func extractFieldInfos(tmpl string) []model.FieldInfo {
+// separatorOrDefault returns the suffix separator in effect for a configured value
+func separatorOrDefault(separator string) string {
+	if separator == "" {
+		return config.DefaultSuffixSeparator
+	}
+	return separator
+}
+
+// ExtractFieldInfos lists the field references of a template in order, splitting suffix notation at suffixSeparator.
+// Each action is classified by its head: a selector such as {{.entity | title}} renders a field, a control
+// action such as {{if .reason}} tests one, and any other action passes fields as arguments, as in {{money .amount}}.
+// Nested selectors such as {{.user.Name}} record the root field user, which is what callers pass.
+// Inside {{range}} and {{with}} the dot is rebound to the element, so only $-rooted selectors
+// such as {{$.total}} are fields there.
+// Fields tested by control actions are listed once, and only when the template does not render them elsewhere,
+// so {{if .reason}}: {{.reason}}{{end}} is not a duplicate placeholder.
+func ExtractFieldInfos(tmpl, suffixSeparator string) []model.FieldInfo {
+	separator := separatorOrDefault(suffixSeparator)
 	results := make([]model.FieldInfo, 0)
+	var controlFields []model.FieldInfo
+	controlPositions := make(map[string]int) // control field name -> number of fields listed before it
+	var rebinds []bool                       // per open block, whether its body rebinds the dot
 	remaining := tmpl
 
 	for {
@@ -182,66 +668,194 @@ func extractFieldInfos(tmpl string) []model.FieldInfo {
 		if end == -1 {
 			break
 		}
+		action := trimAction(remaining[start+2 : start+end])
+		remaining = remaining[start+end+2:]
+		if action == "" || strings.HasPrefix(action, "/*") {
+			continue
+		}
 
-		// Extract the full expression inside {{}}
-		expression := strings.TrimSpace(remaining[start+2 : start+end])
+		head, pipeline := splitActionHead(action)
+		scope := rebinds
+		if head == "else" && len(scope) > 0 {
+			// The pipeline of {{else if .x}} is evaluated with the dot of the enclosing block
+			scope = scope[:len(scope)-1]
+		}
+		rebound := false
+		for _, r := range scope {
+			rebound = rebound || r
+		}
 
-		// Check if it starts with . (field reference)
-		if strings.HasPrefix(expression, ".") {
-			// Remove the leading dot
-			fieldExpression := expression[1:]
+		switch {
+		case strings.HasPrefix(head, ".") || strings.HasPrefix(head, "$."):
+			if !rebound || strings.HasPrefix(head, "$") {
+				if info, ok := selectorField(strings.TrimPrefix(head, "$"), separator); ok {
+					if info.Suffix != "" {
+						info.Separator = suffixSeparator
+					}
+					results = append(results, info)
+				}
+			}
+			// Pipelines such as {{.count | printf "%d of %d" .total}} may pass further fields
+			for _, name := range argumentFields(pipeline, rebound) {
+				results = append(results, model.FieldInfo{Name: name})
+			}
+		case controlKeywords[head]:
+			for _, name := range argumentFields(pipeline, rebound) {
+				if _, seen := controlPositions[name]; !seen {
+					controlPositions[name] = len(results)
+					controlFields = append(controlFields, model.FieldInfo{Name: name})
+				}
+			}
+			rebinds = enterBlock(rebinds, head, pipeline)
+		case head == "end":
+			if len(rebinds) > 0 {
+				rebinds = rebinds[:len(rebinds)-1]
+			}
+		case head == "define" || head == "block":
+			// Named templates are executed with their own dot
+			rebinds = append(rebinds, true)
+		case templateKeywords[head]:
+			// {{template "name" .}}, {{break}} and {{continue}} reference no fields of their own
+		default:
+			// Function calls such as {{money .amount}} and variable assignments pass fields as arguments
+			for _, name := range argumentFields(action, rebound) {
+				results = append(results, model.FieldInfo{Name: name})
+			}
+		}
+	}
 
-			// Split by pipe to handle template functions like .entity | title
-			parts := strings.Split(fieldExpression, "|")
-			fieldPart := strings.TrimSpace(parts[0])
+	// Do not sort to preserve field order
+	return mergeControlFields(results, controlFields, controlPositions)
+}
 
-			// Check for suffix notation (field:suffix)
-			var fieldName, suffix string
-			if colonIndex := strings.Index(fieldPart, ":"); colonIndex != -1 {
-				fieldName = strings.TrimSpace(fieldPart[:colonIndex])
-				suffix = strings.TrimSpace(fieldPart[colonIndex+1:])
-			} else {
-				fieldName = fieldPart
-			}
+// trimAction removes the spaces and trim markers around the text of an action, as in {{- .name -}}
+func trimAction(action string) string {
+	action = strings.TrimSpace(action)
+	if len(action) > 1 && action[0] == '-' && unicode.IsSpace(rune(action[1])) {
+		action = strings.TrimSpace(action[1:])
+	}
+	if n := len(action); n > 1 && action[n-1] == '-' && unicode.IsSpace(rune(action[n-2])) {
+		action = strings.TrimSpace(action[:n-1])
+	}
+	return action
+}
 
-			// Only add non-empty fields
-			if fieldName != "" {
-				results = append(results, model.FieldInfo{
-					Name:   fieldName,
-					Suffix: suffix,
-				})
-			}
+// splitActionHead splits an action into its first operand or keyword and the rest of its pipeline
+func splitActionHead(action string) (head, pipeline string) {
+	end := strings.IndexFunc(action, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '|' || r == '('
+	})
+	if end == -1 {
+		return action, ""
+	}
+	return action[:end], action[end:]
+}
+
+// selectorField returns the field of a selector such as .entity:from or .user.Name without its leading dot.
+// Only the root of a nested selector is a field; the rest selects from the value passed for it.
+func selectorField(selector, separator string) (model.FieldInfo, bool) {
+	selector = strings.TrimPrefix(selector, ".")
+	name, suffix := selector, ""
+	if i := strings.Index(selector, separator); i != -1 {
+		name, suffix = selector[:i], selector[i+len(separator):]
+		if j := strings.Index(suffix, "."); j != -1 {
+			suffix = suffix[:j]
 		}
+	}
+	if i := strings.Index(name, "."); i != -1 {
+		name, suffix = name[:i], ""
+	}
+	if name == "" {
+		return model.FieldInfo{}, false
+	}
+	return model.FieldInfo{Name: name, Suffix: suffix}, true
+}
 
-		remaining = remaining[start+end+2:]
+// argumentFields lists the root fields passed as arguments in a pipeline, ignoring quoted strings.
+// When the dot is rebound only $-rooted arguments such as $.total are fields.
+func argumentFields(pipeline string, rebound bool) []string {
+	var names []string
+	unquoted := quotedStringPattern.ReplaceAllString(pipeline, `""`)
+	for _, match := range fieldArgumentPattern.FindAllStringSubmatch(unquoted, -1) {
+		if rebound && match[1] == "" {
+			continue
+		}
+		names = append(names, match[2])
 	}
+	return names
+}
 
-	// Do not sort to preserve field order
-	return results
+// enterBlock updates the open blocks for a control action: range and with open a block whose body
+// rebinds the dot, if opens one that does not, and else switches the innermost block to its else branch,
+// which keeps the outer dot unless it is an {{else with}}
+func enterBlock(rebinds []bool, keyword, pipeline string) []bool {
+	if keyword != "else" {
+		return append(rebinds, keyword != "if")
+	}
+	if len(rebinds) > 0 {
+		next, _ := splitActionHead(strings.TrimSpace(pipeline))
+		rebinds[len(rebinds)-1] = next == "with"
+	}
+	return rebinds
+}
+
+// mergeControlFields inserts the fields only tested by control actions into results
+// at the position they were first tested
+func mergeControlFields(results, controlFields []model.FieldInfo, positions map[string]int) []model.FieldInfo {
+	if len(controlFields) == 0 {
+		return results
+	}
+	rendered := make(map[string]bool, len(results))
+	for _, info := range results {
+		rendered[info.Name] = true
+	}
+
+	merged := make([]model.FieldInfo, 0, len(results)+len(controlFields))
+	next := 0
+	for _, info := range controlFields {
+		if rendered[info.Name] {
+			continue
+		}
+		merged = append(merged, results[next:positions[info.Name]]...)
+		next = positions[info.Name]
+		merged = append(merged, info)
+	}
+	return append(merged, results[next:]...)
 }
 
 // MessageFileData holds both simplified and raw template data
 type MessageFileData struct {
-	Templates    map[string]map[string]string      // simplified templates for processing
-	RawTemplates map[string]map[string]interface{} // raw templates for documentation
+	Templates    map[string]map[string]string                 // simplified templates for processing
+	RawTemplates map[string]map[string]interface{}            // raw templates for documentation
+	Order        []string                                     // message IDs in source order
+	Lines        map[string]int                               // message ID -> line of definition
+	Comments     map[string]string                            // message ID -> comment attached to the message
+	Metadata     map[string]map[string]map[string]string      // message ID -> locale -> go-i18n hint -> value
+	Variants     map[string]map[string]map[string]interface{} // message ID -> variant name -> locale -> raw template
+	Markdown     map[string]bool                              // message ID -> rendered from markdown to HTML
+	Backend      map[string]string                            // message ID -> declared backend
+	CountHint    map[string]string                            // message ID -> expected magnitude of the count
+	Context      map[string]model.MessageContext              // message ID -> translator context
+	Tags         map[string][]string                          // message ID -> tags
+	NoPlural     map[string][]string                          // message ID -> locales using a single text for every count
 }
 
-func decodeMessageFileWithRaw(file *os.File, ext string) (*MessageFileData, error) {
-	// Read file content once
-	content, err := os.ReadFile(file.Name())
-	if err != nil {
-		return nil, err
-	}
-
+func decodeMessageFileWithRaw(content []byte, ext string) (*MessageFileData, error) {
+	var err error
 	result := &MessageFileData{
 		Templates:    make(map[string]map[string]string),
 		RawTemplates: make(map[string]map[string]interface{}),
 	}
 
+	// Key order is best-effort; decoding errors are reported by the decoders below
+	result.Order, result.Lines, _ = topLevelKeys(content, ext)
+	_, result.Comments = sourceComments(content, ext)
+
 	// First try compound format (map[string]map[string]string)
 	var compoundData map[string]map[string]string
+	// A markdown flag, backend, count hint, context note or single tag decodes as a string too, so such files take the mixed path below
 	if ext == jsonExt {
-		if jsonErr := json.Unmarshal(content, &compoundData); jsonErr == nil {
+		if jsonErr := json.Unmarshal(content, &compoundData); jsonErr == nil && !hasMessageKey(compoundData, MarkdownKey, ContextKey, TagsKey, NoPluralKey, BackendKey, CountHintKey) {
 			result.Templates = compoundData
 			// Convert to interface{} for raw templates
 			for msgID, localeMap := range compoundData {
@@ -253,7 +867,7 @@ func decodeMessageFileWithRaw(file *os.File, ext string) (*MessageFileData, erro
 			return result, nil
 		}
 	} else {
-		if yamlErr := yaml.Unmarshal(content, &compoundData); yamlErr == nil {
+		if yamlErr := yaml.Unmarshal(content, &compoundData); yamlErr == nil && !hasMessageKey(compoundData, MarkdownKey, ContextKey, TagsKey, NoPluralKey, BackendKey, CountHintKey) {
 			result.Templates = compoundData
 			// Convert to interface{} for raw templates
 			for msgID, localeMap := range compoundData {
@@ -268,18 +882,40 @@ func decodeMessageFileWithRaw(file *os.File, ext string) (*MessageFileData, erro
 
 	// Try mixed format that supports both strings and pluralization objects
 	var mixedData map[string]map[string]interface{}
+	var mixedErr error
 	if ext == jsonExt {
-		if jsonErr := json.Unmarshal(content, &mixedData); jsonErr == nil {
-			result.Templates = convertMixedToStringMap(mixedData)
-			result.RawTemplates = mixedData
-			return result, nil
-		}
+		mixedErr = json.Unmarshal(content, &mixedData)
 	} else {
-		if yamlErr := yaml.Unmarshal(content, &mixedData); yamlErr == nil {
-			result.Templates = convertMixedToStringMap(mixedData)
-			result.RawTemplates = mixedData
-			return result, nil
+		mixedErr = yaml.Unmarshal(content, &mixedData)
+	}
+	if mixedErr == nil {
+		if result.Metadata, err = extractMetadata(mixedData); err != nil {
+			return nil, err
+		}
+		if result.Variants, err = extractVariants(mixedData); err != nil {
+			return nil, err
+		}
+		if result.Markdown, err = extractMarkdown(mixedData); err != nil {
+			return nil, err
+		}
+		if result.Backend, err = extractBackend(mixedData); err != nil {
+			return nil, err
+		}
+		if result.CountHint, err = extractCountHint(mixedData); err != nil {
+			return nil, err
 		}
+		if result.Context, err = extractContext(mixedData); err != nil {
+			return nil, err
+		}
+		if result.Tags, err = extractStringLists(mixedData, TagsKey); err != nil {
+			return nil, err
+		}
+		if result.NoPlural, err = extractStringLists(mixedData, NoPluralKey); err != nil {
+			return nil, err
+		}
+		result.Templates = convertMixedToStringMap(mixedData)
+		result.RawTemplates = mixedData
+		return result, nil
 	}
 
 	// Fall back to simple format (map[string]string) and convert to compound format
@@ -296,15 +932,209 @@ func decodeMessageFileWithRaw(file *os.File, ext string) (*MessageFileData, erro
 	// Convert simple format to compound format
 	for id, template := range data {
 		result.Templates[id] = map[string]string{
-			"default": template, // Use "default" as locale for simple format
+			DefaultLocale: template, // Use "default" as locale for simple format
 		}
 		result.RawTemplates[id] = map[string]interface{}{
-			"default": template,
+			DefaultLocale: template,
+		}
+	}
+	return result, nil
+}
+
+// extractMetadata removes the metadata block of every message from data and returns it.
+// Only hints go-i18n understands on a message are accepted.
+func extractMetadata(data map[string]map[string]interface{}) (map[string]map[string]map[string]string, error) {
+	var result map[string]map[string]map[string]string
+	for id, localeData := range data {
+		raw, ok := localeData[MetadataKey]
+		if !ok {
+			continue
+		}
+		delete(localeData, MetadataKey)
+
+		locales, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("message %q: %s must map locales to go-i18n hints", id, MetadataKey)
+		}
+		metadata := make(map[string]map[string]string, len(locales))
+		for locale, rawHints := range locales {
+			hints, ok := rawHints.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("message %q: %s for locale %s must map go-i18n hints to values", id, MetadataKey, locale)
+			}
+			metadata[locale] = make(map[string]string, len(hints))
+			for key, value := range hints {
+				if !slices.Contains(metadataKeys, key) {
+					return nil, fmt.Errorf("message %q: unsupported %s key %q for locale %s: must be one of %v",
+						id, MetadataKey, key, locale, metadataKeys)
+				}
+				text, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("message %q: %s key %q for locale %s must be a string", id, MetadataKey, key, locale)
+				}
+				metadata[locale][key] = text
+			}
+		}
+		if result == nil {
+			result = make(map[string]map[string]map[string]string)
+		}
+		result[id] = metadata
+	}
+	return result, nil
+}
+
+// extractBackend removes the backend declared by every message from data and returns the declarations
+func extractBackend(data map[string]map[string]interface{}) (map[string]string, error) {
+	var result map[string]string
+	for id, localeData := range data {
+		raw, ok := localeData[BackendKey]
+		if !ok {
+			continue
+		}
+		delete(localeData, BackendKey)
+
+		backend, ok := raw.(string)
+		if !ok || (backend != config.BackendGoI18n && backend != config.BackendFilesystem) {
+			return nil, fmt.Errorf("message %q: %s must be %q or %q", id, BackendKey, config.BackendGoI18n, config.BackendFilesystem)
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[id] = backend
+	}
+	return result, nil
+}
+
+// extractCountHint removes the count hint of every message from data and returns the hints
+func extractCountHint(data map[string]map[string]interface{}) (map[string]string, error) {
+	var result map[string]string
+	for id, localeData := range data {
+		raw, ok := localeData[CountHintKey]
+		if !ok {
+			continue
+		}
+		delete(localeData, CountHintKey)
+
+		hint, ok := raw.(string)
+		if !ok || strings.TrimSpace(hint) == "" {
+			return nil, fmt.Errorf("message %q: %s must be a non-empty string", id, CountHintKey)
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[id] = hint
+	}
+	return result, nil
+}
+
+// extractMarkdown removes the markdown flag of every message from data and returns the flagged messages
+func extractMarkdown(data map[string]map[string]interface{}) (map[string]bool, error) {
+	var result map[string]bool
+	for id, localeData := range data {
+		raw, ok := localeData[MarkdownKey]
+		if !ok {
+			continue
+		}
+		delete(localeData, MarkdownKey)
+
+		flag, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("message %q: %s must be true or false", id, MarkdownKey)
+		}
+		if !flag {
+			continue
 		}
+		if result == nil {
+			result = make(map[string]bool)
+		}
+		result[id] = true
 	}
 	return result, nil
 }
 
+// extractContext removes the context block of every message from data and returns it.
+// A context is either a note or a map with note and screenshot.
+func extractContext(data map[string]map[string]interface{}) (map[string]model.MessageContext, error) {
+	var result map[string]model.MessageContext
+	for id, localeData := range data {
+		raw, ok := localeData[ContextKey]
+		if !ok {
+			continue
+		}
+		delete(localeData, ContextKey)
+
+		var context model.MessageContext
+		switch v := raw.(type) {
+		case string:
+			context.Note = v
+		case map[string]interface{}:
+			for key, value := range v {
+				text, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("message %q: %s key %q must be a string", id, ContextKey, key)
+				}
+				switch key {
+				case "note":
+					context.Note = text
+				case "screenshot":
+					context.Screenshot = text
+				default:
+					return nil, fmt.Errorf("message %q: unsupported %s key %q: must be note or screenshot", id, ContextKey, key)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("message %q: %s must be a note or a map with note and screenshot", id, ContextKey)
+		}
+		if result == nil {
+			result = make(map[string]model.MessageContext)
+		}
+		result[id] = context
+	}
+	return result, nil
+}
+
+// extractStringLists removes the list under key, such as tags, from every message of data and returns the lists
+func extractStringLists(data map[string]map[string]interface{}, key string) (map[string][]string, error) {
+	var result map[string][]string
+	for id, localeData := range data {
+		raw, ok := localeData[key]
+		if !ok {
+			continue
+		}
+		delete(localeData, key)
+
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("message %q: %s must be a list of strings", id, key)
+		}
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			value, ok := item.(string)
+			if !ok || value == "" {
+				return nil, fmt.Errorf("message %q: %s must be a list of strings", id, key)
+			}
+			values = append(values, value)
+		}
+		if result == nil {
+			result = make(map[string][]string)
+		}
+		result[id] = values
+	}
+	return result, nil
+}
+
+// hasMessageKey reports whether any message of data has one of the reserved keys
+func hasMessageKey(data map[string]map[string]string, keys ...string) bool {
+	for _, localeData := range data {
+		for _, key := range keys {
+			if _, ok := localeData[key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // convertMixedToStringMap converts mixed format (string or pluralization object) to string-only format
 func convertMixedToStringMap(mixedData map[string]map[string]interface{}) map[string]map[string]string {
 	result := make(map[string]map[string]string)
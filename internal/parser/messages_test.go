@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hacomono-lib/go-i18ngen/internal/model"
@@ -98,16 +100,141 @@ func (s *ParserTestSuite) TestExtractFieldInfos() {
 			template: "{{.field:input_value}} to {{.field:display_name}}",
 			expected: []model.FieldInfo{{Name: "field", Suffix: "input_value"}, {Name: "field", Suffix: "display_name"}},
 		},
+		{
+			name:     "function call arguments",
+			template: `Total: {{money .amount}}, {{printf "%s (.ignored)" (shorten .label)}}{{locale "en" "!"}}`,
+			expected: []model.FieldInfo{{Name: "amount"}, {Name: "label"}},
+		},
+		{
+			name:     "fields tested by control actions",
+			template: "{{if .paid}}paid{{end}}",
+			expected: []model.FieldInfo{{Name: "paid"}},
+		},
+		{
+			name:     "conditional field rendered in its section",
+			template: "User not found{{if .reason}}: {{.reason}}{{end}}",
+			expected: []model.FieldInfo{{Name: "reason"}},
+		},
+		{
+			name:     "control fields keep their position",
+			template: "{{.user}}{{if .note}} ({{.other}}){{else if .hint}}?{{end}} {{- with .extra}}{{.}}{{end}} {{.last}}",
+			expected: []model.FieldInfo{{Name: "user"}, {Name: "note"}, {Name: "other"}, {Name: "hint"}, {Name: "extra"}, {Name: "last"}},
+		},
+		{
+			name:     "ranged template",
+			template: "{{range .items}}- {{.}}{{end}}{{.total}}",
+			expected: []model.FieldInfo{{Name: "items"}, {Name: "total"}},
+		},
+		{
+			name:     "negated condition with function",
+			template: "{{if not .reason}}no reason{{end}}",
+			expected: []model.FieldInfo{{Name: "reason"}},
+		},
+		{
+			name:     "nested selector records its root field",
+			template: "{{.user.Name}} ({{.user.Email | lower}})",
+			expected: []model.FieldInfo{{Name: "user"}, {Name: "user"}},
+		},
+		{
+			name:     "nested selector after suffix notation",
+			template: "{{.entity:from.Name}}",
+			expected: []model.FieldInfo{{Name: "entity", Suffix: "from"}},
+		},
+		{
+			name:     "pipeline without spaces",
+			template: "{{.entity|title}}",
+			expected: []model.FieldInfo{{Name: "entity"}},
+		},
+		{
+			name:     "pipeline passing further fields",
+			template: `{{.count | printf "%d of %d (.ignored)" .total}}`,
+			expected: []model.FieldInfo{{Name: "count"}, {Name: "total"}},
+		},
+		{
+			name:     "trim markers",
+			template: "{{- .entity -}} and {{- .reason:short -}}",
+			expected: []model.FieldInfo{{Name: "entity"}, {Name: "reason", Suffix: "short"}},
+		},
+		{
+			name:     "comments and bare dots",
+			template: "{{/* .ignored */}}{{.}}{{ . }}{{.name}}",
+			expected: []model.FieldInfo{{Name: "name"}},
+		},
+		{
+			name:     "range body selects from the element",
+			template: "{{range .items}}{{.Name}} of {{$.owner}}{{end}} {{.total}}",
+			expected: []model.FieldInfo{{Name: "items"}, {Name: "owner"}, {Name: "total"}},
+		},
+		{
+			name:     "with body selects from the value",
+			template: "{{with .user}}{{.Name | title}}{{else}}{{.guest}}{{end}}",
+			expected: []model.FieldInfo{{Name: "user"}, {Name: "guest"}},
+		},
+		{
+			name:     "else with tests an outer field",
+			template: "{{with .user}}{{.Name}}{{else with .team}}{{.Title}}{{end}}",
+			expected: []model.FieldInfo{{Name: "user"}, {Name: "team"}},
+		},
+		{
+			name:     "if body keeps the dot inside range",
+			template: "{{range .items}}{{if .Done}}{{.Name}}{{end}}{{end}}",
+			expected: []model.FieldInfo{{Name: "items"}},
+		},
+		{
+			name:     "variables and templates",
+			template: `{{$n := len .items}}{{$n}} {{template "row" .}} {{$.total}}`,
+			expected: []model.FieldInfo{{Name: "items"}, {Name: "total"}},
+		},
+		{
+			name:     "parenthesized pipeline",
+			template: "{{(index .names 0)}}",
+			expected: []model.FieldInfo{{Name: "names"}},
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
-			result := extractFieldInfos(tt.template)
+			result := ExtractFieldInfos(tt.template, "")
 			s.Equal(tt.expected, result, "Field extraction does not match expected values")
 		})
 	}
 }
 
+func (s *ParserTestSuite) TestExtractFieldInfosCustomSeparator() {
+	result := ExtractFieldInfos("{{.entity__from}} {{.user_name}} {{.entity__to | upper}}", "__")
+	s.Equal([]model.FieldInfo{
+		{Name: "entity", Suffix: "from", Separator: "__"},
+		{Name: "user_name"},
+		{Name: "entity", Suffix: "to", Separator: "__"},
+	}, result)
+
+	// With a custom separator, colons are no longer suffix notation
+	s.Equal([]model.FieldInfo{{Name: "entity:from"}}, ExtractFieldInfos("{{.entity:from}}", "__"))
+}
+
+func (s *ParserTestSuite) TestValidateSuffixSeparator() {
+	for _, separator := range []string{"", ":", "__", "-", "::"} {
+		s.NoError(ValidateSuffixSeparator(separator), separator)
+	}
+
+	tests := []struct {
+		separator string
+		expected  string
+	}{
+		{"_", "a single underscore is part of snake_case placeholder names"},
+		{"x", "letters and digits are part of placeholder names"},
+		{"_1", "letters and digits are part of placeholder names"},
+		{".", "is part of the template syntax"},
+		{" ", "is part of the template syntax"},
+		{"|", "is part of the template syntax"},
+	}
+	for _, tt := range tests {
+		err := ValidateSuffixSeparator(tt.separator)
+		s.Require().Error(err, tt.separator)
+		s.Contains(err.Error(), tt.expected)
+	}
+}
+
 func (s *ParserTestSuite) TestParseMessages() {
 	// Create test message file with only valid syntax (no duplicate placeholders)
 	messageFile := filepath.Join(s.tempDir, "messages.yaml")
@@ -125,7 +252,7 @@ TemplateFunctionExample:
 
 	// Execute ParseMessages
 	pattern := filepath.Join(s.tempDir, "messages.yaml")
-	results, err := ParseMessages(pattern)
+	results, err := ParseMessages(pattern, "", nil)
 	s.Require().NoError(err)
 
 	// Verify results
@@ -157,7 +284,7 @@ TemplateFunctionExample:
 
 func (s *ParserTestSuite) TestParseMessagesWithJSON() {
 	// Create JSON format test message file with suffix notation
-	messageFile := filepath.Join(s.tempDir, "messages.json")
+	messageFile := filepath.Join(s.tempDir, "messages.json", "")
 	messageContent := `{
   "ValidationError": {
     "ja": "{{.field:input}}の{{.field:display | upper}}検証エラー",
@@ -167,8 +294,8 @@ func (s *ParserTestSuite) TestParseMessagesWithJSON() {
 	s.Require().NoError(os.WriteFile(messageFile, []byte(messageContent), 0644))
 
 	// Execute ParseMessages
-	pattern := filepath.Join(s.tempDir, "messages.json")
-	results, err := ParseMessages(pattern)
+	pattern := filepath.Join(s.tempDir, "messages.json", "")
+	results, err := ParseMessages(pattern, "", nil)
 	s.Require().NoError(err)
 
 	// Verify results
@@ -180,6 +307,317 @@ func (s *ParserTestSuite) TestParseMessagesWithJSON() {
 	s.Equal(expectedFields, validationError.FieldInfos, "Verify that suffix notation and template function processing work with JSON format")
 }
 
+func (s *ParserTestSuite) TestParseMessagesSourceOrder() {
+	dir := filepath.Join(s.tempDir, "source_order")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+
+	yamlContent := `Zebra:
+  en: "zebra"
+Apple:
+  en: "apple"
+`
+	jsonContent := `{"Mango": {"en": "mango"}, "Banana": {"en": "banana"}}`
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(yamlContent), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "b.json"), []byte(jsonContent), 0644))
+
+	results, err := ParseMessages(filepath.Join(dir, "*"), "", nil)
+	s.Require().NoError(err)
+	s.Require().Len(results, 4)
+
+	var ids []string
+	for i, result := range results {
+		ids = append(ids, result.ID)
+		s.Equal(i, result.Position)
+	}
+	s.Equal([]string{"Zebra", "Apple", "Mango", "Banana"}, ids)
+}
+
+func (s *ParserTestSuite) TestParsePlaceholdersSourceOrder() {
+	dir := filepath.Join(s.tempDir, "placeholder_order")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "status.yaml"), []byte(`pending:
+  en: "Pending"
+done:
+  en: "Done"
+`), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "entity.yaml"), []byte(`user:
+  en: "User"
+`), 0644))
+
+	results, err := ParsePlaceholders(filepath.Join(dir, "*.yaml"), []string{"en"}, true)
+	s.Require().NoError(err)
+	s.Require().Len(results, 2)
+
+	// Files are visited in glob (lexical) order
+	s.Equal("entity", results[0].Kind)
+	s.Equal(0, results[0].Position)
+	s.Equal("status", results[1].Kind)
+	s.Equal(1, results[1].Position)
+	s.Equal([]string{"pending", "done"}, results[1].ItemOrder)
+}
+
+func (s *ParserTestSuite) TestResolveDefaultLocale() {
+	dir := filepath.Join(s.tempDir, "simple_messages")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "messages.yaml"), []byte(`Hello: "Hello {{.name}}"
+`), 0644))
+
+	results, err := ParseMessages(filepath.Join(dir, "*.yaml"), "", nil)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Equal("Hello {{.name}}", results[0].Templates[DefaultLocale])
+
+	resolved, err := ResolveDefaultLocale(results, "ja")
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"ja": "Hello {{.name}}"}, resolved[0].Templates)
+	s.Equal(map[string]interface{}{"ja": "Hello {{.name}}"}, resolved[0].RawTemplates)
+	s.Equal([]model.FieldInfo{{Name: "name"}}, resolved[0].FieldInfos)
+
+	// Compound messages are left untouched
+	compound := []model.MessageSource{{ID: "Bye", Templates: map[string]string{"en": "Bye"}}}
+	resolved, err = ResolveDefaultLocale(compound, "ja")
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"en": "Bye"}, resolved[0].Templates)
+}
+
+func (s *ParserTestSuite) TestResolveDefaultLocaleMixedFiles() {
+	dir := filepath.Join(s.tempDir, "mixed_messages")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "compound.yaml"), []byte(`Bye:
+  ja: "さようなら"
+  en: "Bye"
+`), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "simple.yaml"), []byte(`Hello: "こんにちは"
+`), 0644))
+
+	results, err := ParseMessages(filepath.Join(dir, "*.yaml"), "", nil)
+	s.Require().NoError(err)
+	resolved, err := ResolveDefaultLocale(results, "ja")
+	s.Require().NoError(err)
+	s.Require().Len(resolved, 2)
+	s.Equal(map[string]string{"ja": "さようなら", "en": "Bye"}, resolved[0].Templates)
+	s.Equal(map[string]string{"ja": "こんにちは"}, resolved[1].Templates)
+
+	// "default" next to the primary locale leaves no way to tell which translation wins
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "compound.yaml"), []byte(`Bye:
+  default: "Bye"
+  ja: "さようなら"
+`), 0644))
+	results, err = ParseMessages(filepath.Join(dir, "*.yaml"), "", nil)
+	s.Require().NoError(err)
+	_, err = ResolveDefaultLocale(results, "ja")
+	s.Require().Error(err)
+	s.Contains(err.Error(), `message "Bye" in `)
+	s.Contains(err.Error(), `has templates for both "default" and the primary locale "ja"`)
+}
+
+func (s *ParserTestSuite) TestParseMessagesFieldLocale() {
+	dir := filepath.Join(s.tempDir, "field_locale")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+	pattern := filepath.Join(dir, "*.yaml")
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "messages.yaml"), []byte(`Moved:
+  en: "Moved {{.item}} to {{.place}}"
+  ja: "{{.place}}に{{.item}}を移動しました"
+`), 0644))
+
+	tests := []struct {
+		name    string
+		locales []string
+		want    []model.FieldInfo
+	}{
+		{"sorted fallback without locales", nil, []model.FieldInfo{{Name: "item"}, {Name: "place"}}},
+		{"first locale wins", []string{"ja", "en"}, []model.FieldInfo{{Name: "place"}, {Name: "item"}}},
+		{"undefined locales are skipped", []string{"fr", "en"}, []model.FieldInfo{{Name: "item"}, {Name: "place"}}},
+		{"sorted fallback when no locale matches", []string{"fr"}, []model.FieldInfo{{Name: "item"}, {Name: "place"}}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			results, err := ParseMessages(pattern, "", tt.locales)
+			s.Require().NoError(err)
+			s.Require().Len(results, 1)
+			s.Equal(tt.want, results[0].FieldInfos)
+		})
+	}
+}
+
+func (s *ParserTestSuite) TestFindUnconfiguredLocales() {
+	messages := []model.MessageSource{
+		{ID: "Hello", Templates: map[string]string{"en": "Hello", "jp": "こんにちは"}, Location: model.SourceLocation{File: "messages.yaml", Line: 1}},
+		{ID: "Simple", Templates: map[string]string{DefaultLocale: "Simple"}},
+		{ID: "Bye", Templates: map[string]string{"en": "Bye", "ja": "さようなら"}},
+	}
+
+	s.Equal([]string{`message "Hello" (locale: jp) in messages.yaml:1`}, FindUnconfiguredLocales(messages, []string{"en", "ja"}))
+	s.Empty(FindUnconfiguredLocales(messages[1:], []string{"en", "ja"}))
+}
+
+func (s *ParserTestSuite) TestFindMissingPluralForms() {
+	forms := map[string]interface{}{"one": "{{.Count}} item", "other": "{{.Count}} items"}
+	messages := []model.MessageSource{
+		{ID: "Items", RawTemplates: map[string]interface{}{"en": forms, "fr": "{{.Count}} articles", "de": "{{.Count}} Artikel", "ja": "{{.Count}}件"},
+			Location: model.SourceLocation{File: "messages.yaml", Line: 1}},
+		{ID: "Complete", RawTemplates: map[string]interface{}{"en": forms, "fr": forms}},
+		{ID: "Plain", RawTemplates: map[string]interface{}{"en": "Hello", "fr": "Bonjour"}},
+	}
+
+	s.Equal([]string{`message "Items" in messages.yaml:1 (locales without plural forms: de, fr)`}, FindMissingPluralForms(messages, nil))
+	s.Equal([]string{`message "Items" in messages.yaml:1 (locales without plural forms: fr)`}, FindMissingPluralForms(messages, []string{"de"}))
+	s.Empty(FindMissingPluralForms(messages[1:], nil))
+
+	messages[0].NoPlural = []string{"de", "fr"}
+	s.Empty(FindMissingPluralForms(messages, nil))
+}
+
+func (s *ParserTestSuite) TestFindMissingPrimaryLocale() {
+	messages := []model.MessageSource{
+		{ID: "Hello", Templates: map[string]string{"en": "Hello", "ja": "こんにちは"}},
+		{ID: "Foo", Templates: map[string]string{"en": "Foo"}, Location: model.SourceLocation{File: "messages.yaml", Line: 3}},
+	}
+
+	s.Equal([]string{`message "Foo" in messages.yaml:3`}, FindMissingPrimaryLocale(messages, "ja"))
+	s.Empty(FindMissingPrimaryLocale(messages, "en"))
+}
+
+func (s *ParserTestSuite) TestValidateMessageLocales() {
+	messages := []model.MessageSource{
+		{ID: "Hello", Templates: map[string]string{"en": "Hello", "fr": "Bonjour"}},
+		{ID: "Foo", Templates: map[string]string{"de": "Foo", "fr": "Foo"}, Location: model.SourceLocation{File: "messages.yaml", Line: 3}},
+	}
+
+	s.NoError(ValidateMessageLocales(messages[:1], []string{"en", "ja"}))
+
+	err := ValidateMessageLocales(messages, []string{"en", "ja"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), `message "Foo" in messages.yaml:3`)
+	s.Contains(err.Error(), "found: [de fr]")
+}
+
+func (s *ParserTestSuite) TestFindEmptyTemplates() {
+	location := model.SourceLocation{File: "messages.yaml", Line: 2}
+	messages := []model.MessageSource{
+		{ID: "Hello", RawTemplates: map[string]interface{}{"en": "Hello", "ja": "  "}, Location: location},
+		{ID: "Count", RawTemplates: map[string]interface{}{
+			"en": map[string]interface{}{"one": "{{.Count}} item", "other": ""},
+			"ja": map[string]string{"other": "{{.Count}}個"},
+		}, Location: location},
+		{ID: "Missing", RawTemplates: map[string]interface{}{"en": nil}, Location: location},
+	}
+
+	s.Equal([]string{
+		`message "Count" (locale: en, form: other) in messages.yaml:2`,
+		`message "Hello" (locale: ja) in messages.yaml:2`,
+		`message "Missing" (locale: en) in messages.yaml:2`,
+	}, FindEmptyTemplates(messages))
+	s.Empty(FindEmptyTemplates(messages[:0]))
+}
+
+func (s *ParserTestSuite) TestAutofill() {
+	location := model.SourceLocation{File: "messages.yaml", Line: 1}
+	messages := []model.MessageSource{
+		{
+			ID:           "Hello",
+			Templates:    map[string]string{"en": "Hello"},
+			RawTemplates: map[string]interface{}{"en": "Hello"},
+			Location:     location,
+		},
+		{
+			ID:           "Bye",
+			Templates:    map[string]string{"en": "Bye", "ja": "さようなら"},
+			RawTemplates: map[string]interface{}{"en": "Bye", "ja": "さようなら"},
+			Location:     location,
+		},
+	}
+
+	filled := AutofillMessages(messages, "en", []string{"en", "ja", "fr"})
+	s.Equal([]string{
+		`message "Hello" (locale: ja) in messages.yaml:1`,
+		`message "Hello" (locale: fr) in messages.yaml:1`,
+		`message "Bye" (locale: fr) in messages.yaml:1`,
+	}, filled)
+	s.Equal("Hello", messages[0].Templates["ja"])
+	s.Equal("Hello", messages[0].RawTemplates["fr"])
+	s.Equal(map[string]string{"ja": "en", "fr": "en"}, messages[0].Autofilled)
+	s.Equal("さようなら", messages[1].Templates["ja"], "existing translations are kept")
+
+	placeholders := []model.PlaceholderSource{{
+		Kind:  "entity",
+		Items: map[string]map[string]string{"user": {"en": "User"}, "group": {"ja": "グループ"}},
+	}}
+	filled = AutofillPlaceholders(placeholders, "en", []string{"en", "ja"})
+	s.Equal([]string{"placeholder entity.user (locale: ja)"}, filled)
+	s.Equal("User", placeholders[0].Items["user"]["ja"])
+	s.Equal(map[string]map[string]string{"user": {"ja": "en"}}, placeholders[0].Autofilled)
+}
+
+func (s *ParserTestSuite) TestParseLocations() {
+	dir := filepath.Join(s.tempDir, "locations")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+
+	messageFile := filepath.Join(dir, "messages.json", "")
+	s.Require().NoError(os.WriteFile(messageFile, []byte(`{
+  "first": {"en": "First"},
+
+  "second": {
+    "en": "Second"
+  }
+}
+`), 0644))
+	placeholderFile := filepath.Join(dir, "entity.yaml")
+	s.Require().NoError(os.WriteFile(placeholderFile, []byte(`user:
+  en: "User"
+# Comment lines do not count as definitions
+product:
+  en: "Product"
+`), 0644))
+
+	messages, err := ParseMessages(messageFile, "", nil)
+	s.Require().NoError(err)
+	s.Require().Len(messages, 2)
+	s.Equal(model.SourceLocation{File: messageFile, Line: 2}, messages[0].Location)
+	s.Equal(model.SourceLocation{File: messageFile, Line: 4}, messages[1].Location)
+
+	placeholders, err := ParsePlaceholders(placeholderFile, []string{"en"}, true)
+	s.Require().NoError(err)
+	s.Require().Len(placeholders, 1)
+	s.Equal(map[string]model.SourceLocation{
+		"user":    {File: placeholderFile, Line: 1},
+		"product": {File: placeholderFile, Line: 4},
+	}, placeholders[0].ItemLocations)
+}
+
+func (s *ParserTestSuite) TestParsePlaceholdersComments() {
+	dir := filepath.Join(s.tempDir, "placeholder_comments")
+	s.Require().NoError(os.MkdirAll(dir, 0755))
+
+	content := `# Entities referenced in error messages
+
+# The person signed in to the app
+user:
+  ja: "ユーザー"
+  en: "User"
+# Someone invited to a workspace
+# who has not signed up yet
+member: # not an admin
+  ja: "メンバー"
+  en: "Member"
+product:
+  ja: "製品"
+  en: "Product"
+`
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "entity.yaml"), []byte(content), 0644))
+
+	results, err := ParsePlaceholders(filepath.Join(dir, "*.yaml"), []string{"ja", "en"}, true)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+
+	s.Equal("Entities referenced in error messages", results[0].Description)
+	s.Equal(map[string]string{
+		"user":   "The person signed in to the app",
+		"member": "Someone invited to a workspace\nwho has not signed up yet\nnot an admin",
+	}, results[0].ItemDescriptions)
+}
+
 func (s *ParserTestSuite) TestParseMessagesDuplicatePlaceholderValidation() {
 	// Create test message file with duplicate placeholders (should fail)
 	messageFile := filepath.Join(s.tempDir, "invalid_messages.yaml")
@@ -191,21 +629,447 @@ func (s *ParserTestSuite) TestParseMessagesDuplicatePlaceholderValidation() {
 
 	// Execute ParseMessages - should return error
 	pattern := filepath.Join(s.tempDir, "invalid_messages.yaml")
-	results, err := ParseMessages(pattern)
+	results, err := ParseMessages(pattern, "", nil)
 	s.Error(err, "Should return error for duplicate placeholders")
 	s.Contains(err.Error(), "duplicate placeholder", "Error message should mention duplicate placeholder")
 	s.Contains(err.Error(), "suffix notation", "Error message should suggest suffix notation")
 	s.Nil(results)
 }
 
+func (s *ParserTestSuite) TestParseMessagesInvalidPluralForm() {
+	messageFile := filepath.Join(s.tempDir, "invalid_plural.yaml")
+	messageContent := `UserCount:
+  ja: "{{.Count}}人のユーザー"
+  en:
+    one: "{{.Count}} user"
+    ohter: "{{.Count}} users"
+`
+	s.Require().NoError(os.WriteFile(messageFile, []byte(messageContent), 0644))
+
+	results, err := ParseMessages(messageFile, "", nil)
+	s.Require().Error(err, "Should return error for unknown plural categories")
+	s.Contains(err.Error(), `message "UserCount" (locale: en)`)
+	s.Contains(err.Error(), `invalid plural form "ohter"`)
+	s.Nil(results)
+}
+
+func (s *ParserTestSuite) TestValidateSuffixKeys() {
+	tests := []struct {
+		name      string
+		templates map[string]string
+		expected  string
+	}{
+		{
+			name: "suffix notation in every locale",
+			templates: map[string]string{
+				"ja": "{{.entity:from}}から{{.entity:to}}へ",
+				"en": "From {{.entity:from}} to {{.entity:to}}",
+			},
+		},
+		{
+			name: "unrelated plain field",
+			templates: map[string]string{
+				"ja": "{{.entity:from}}の{{.entityName}}",
+				"en": "{{.entityName}} of {{.entity:from}}",
+			},
+		},
+		{
+			name: "resolved key in another locale",
+			templates: map[string]string{
+				"ja": "{{.entity:from}}から",
+				"en": "From {{.entityFrom}}",
+			},
+			expected: "locale en uses {{.entityFrom}} while suffix notation {{.entity:from}} resolves to the same key",
+		},
+		{
+			name: "resolved key with template function in the same locale",
+			templates: map[string]string{
+				"en": "{{.entity:from}} and {{.entityFrom | upper}}",
+			},
+			expected: "locale en uses {{.entityFrom}}",
+		},
+		{
+			name: "resolved key with snake case suffix",
+			templates: map[string]string{
+				"ja": "{{.field:display_name}}",
+				"en": "{{.fieldDisplayName}}",
+			},
+			expected: "suffix notation {{.field:display_name}}",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := validateSuffixKeys(tt.templates, "")
+			if tt.expected == "" {
+				s.NoError(err)
+				return
+			}
+			s.Require().Error(err)
+			s.Contains(err.Error(), tt.expected)
+		})
+	}
+}
+
+func (s *ParserTestSuite) TestParseMessagesMixedSuffixNotation() {
+	messageFile := filepath.Join(s.tempDir, "mixed_suffix.yaml")
+	messageContent := `Transfer:
+  ja: "{{.entity:from}}から{{.entity:to}}へ"
+  en: "From {{.entityFrom}} to {{.entity:to}}"
+`
+	s.Require().NoError(os.WriteFile(messageFile, []byte(messageContent), 0644))
+
+	results, err := ParseMessages(messageFile, "", nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `message "Transfer"`)
+	s.Contains(err.Error(), "{{.entityFrom}}")
+	s.Nil(results)
+}
+
 func (s *ParserTestSuite) TestParseMessagesEmptyPattern() {
 	// Test with non-existent pattern
-	results, err := ParseMessages("/nonexistent/*.yaml")
+	results, err := ParseMessages("/nonexistent/*.yaml", "", nil)
 	s.Error(err, "Should return error for non-existent patterns")
 	s.Contains(err.Error(), "no message files found", "Error should indicate no files found")
 	s.Nil(results)
 }
 
+func (s *ParserTestSuite) TestParseMessagesParallel() {
+	dir := s.T().TempDir()
+	for i := 0; i < 20; i++ {
+		content := fmt.Sprintf("Message%02dA:\n  en: \"A {{.name}}\"\nMessage%02dB:\n  en: \"B\"\n", i, i)
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, fmt.Sprintf("m%02d.yaml", i)), []byte(content), 0644))
+	}
+	pattern := filepath.Join(dir, "*.yaml")
+
+	sequential, err := ParseMessagesParallel(pattern, "", nil, 1, "")
+	s.Require().NoError(err)
+	s.Len(sequential, 40)
+	s.Equal("Message00A", sequential[0].ID)
+	s.Equal("Message19B", sequential[39].ID)
+
+	for _, maxParallel := range []int{0, 3, 100} {
+		parallel, err := ParseMessagesParallel(pattern, "", nil, maxParallel, "")
+		s.Require().NoError(err)
+		s.Equal(sequential, parallel, "max parallel %d", maxParallel)
+	}
+
+	// The error of the first failing file is reported, as when parsing sequentially
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "m05.yaml"), []byte("Broken: [\n"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "m15.yaml"), []byte("Broken: [\n"), 0644))
+	for _, maxParallel := range []int{1, 8} {
+		_, err := ParseMessagesParallel(pattern, "", nil, maxParallel, "")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "m05.yaml")
+	}
+}
+
+func (s *ParserTestSuite) TestParseMessagesReader() {
+	s.Run("yaml", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`# Greeting
+Hello:
+  en: "Hello {{.name}}"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal("Hello", results[0].ID)
+		s.Equal("Hello {{.name}}", results[0].Templates["en"])
+		s.Equal(model.SourceLocation{File: "<stdin>", Line: 2}, results[0].Location)
+		s.Equal("Greeting", results[0].Description)
+	})
+
+	s.Run("json by extension", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`{"Hello": {"en": "Hello"}}`), "messages.json", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal("Hello", results[0].Templates["en"])
+	})
+
+	s.Run("invalid document", func() {
+		_, err := ParseMessagesReader(strings.NewReader("Hello: [unclosed\n"), "<stdin>", "", nil)
+		s.Error(err)
+		s.Contains(err.Error(), "<stdin>", "")
+	})
+}
+
+func (s *ParserTestSuite) TestParseMessagesMetadata() {
+	s.Run("metadata is split from translations", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Braces:
+  en: "Use {{ and }}"
+  metadata:
+    en:
+      leftDelim: "<<"
+      rightDelim: ">>"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal(map[string]string{"en": "Use {{ and }}"}, results[0].Templates)
+		s.Equal(map[string]map[string]string{"en": {"leftDelim": "<<", "rightDelim": ">>"}}, results[0].Metadata)
+	})
+
+	s.Run("unsupported key", func() {
+		_, err := ParseMessagesReader(strings.NewReader(`Braces:
+  en: "Use {{ and }}"
+  metadata:
+    en:
+      delim: "<<"
+`), "<stdin>", "", nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `unsupported metadata key "delim"`)
+	})
+
+	s.Run("locale without template", func() {
+		_, err := ParseMessagesReader(strings.NewReader(`Braces:
+  en: "Use {{ and }}"
+  metadata:
+    ja:
+      leftDelim: "<<"
+`), "<stdin>", "", nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "metadata for locale ja has no template")
+	})
+}
+
+func (s *ParserTestSuite) TestParseMessagesMarkdown() {
+	s.Run("flag is split from translations", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Welcome:
+  markdown: true
+  en: "**Welcome**"
+Plain:
+  en: "Plain"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+		s.Equal(map[string]string{"en": "**Welcome**"}, results[0].Templates)
+		s.True(results[0].Markdown)
+		s.False(results[1].Markdown)
+	})
+
+	s.Run("JSON", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`{"Welcome": {"markdown": true, "en": "**Welcome**"}}`), "messages.json", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal(map[string]string{"en": "**Welcome**"}, results[0].Templates)
+		s.True(results[0].Markdown)
+	})
+
+	s.Run("not a boolean", func() {
+		_, err := ParseMessagesReader(strings.NewReader(`Welcome:
+  markdown: "yes"
+  en: "**Welcome**"
+`), "<stdin>", "", nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `message "Welcome": markdown must be true or false`)
+	})
+}
+
+func (s *ParserTestSuite) TestParseMessagesContext() {
+	s.Run("note is split from translations", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Save:
+  context: "Button label in the profile editor"
+  ja: "保存"
+  en: "Save"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal(map[string]string{"ja": "保存", "en": "Save"}, results[0].Templates)
+		s.Equal(model.MessageContext{Note: "Button label in the profile editor"}, results[0].Context)
+	})
+
+	s.Run("note and screenshot", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Save:
+  context:
+    note: "Button label in the profile editor"
+    screenshot: "https://example.com/profile.png"
+  en: "Save"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal(map[string]string{"en": "Save"}, results[0].Templates)
+		s.Equal(model.MessageContext{
+			Note:       "Button label in the profile editor",
+			Screenshot: "https://example.com/profile.png",
+		}, results[0].Context)
+	})
+
+	s.Run("JSON", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`{"Save": {"context": "Button label", "en": "Save"}}`), "messages.json", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal(map[string]string{"en": "Save"}, results[0].Templates)
+		s.Equal("Button label", results[0].Context.Note)
+	})
+
+	s.Run("unsupported key", func() {
+		_, err := ParseMessagesReader(strings.NewReader(`Save:
+  context:
+    image: "profile.png"
+  en: "Save"
+`), "<stdin>", "", nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `message "Save": unsupported context key "image"`)
+	})
+}
+
+func (s *ParserTestSuite) TestParseMessagesTags() {
+	s.Run("tags are split from translations", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Welcome:
+  tags: [email, transactional]
+  en: "Welcome"
+Plain:
+  en: "Plain"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+		s.Equal(map[string]string{"en": "Welcome"}, results[0].Templates)
+		s.Equal([]string{"email", "transactional"}, results[0].Tags)
+		s.Nil(results[1].Tags)
+	})
+
+	s.Run("not a list", func() {
+		_, err := ParseMessagesReader(strings.NewReader(`Welcome:
+  tags: email
+  en: "Welcome"
+`), "<stdin>", "", nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `message "Welcome": tags must be a list of strings`)
+	})
+}
+
+func (s *ParserTestSuite) TestParseMessagesNoPlural() {
+	s.Run("locales are split from translations", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Items:
+  no_plural: [fr]
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+  fr: "{{.Count}} article(s)"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal([]string{"fr"}, results[0].NoPlural)
+		s.NotContains(results[0].Templates, NoPluralKey)
+	})
+
+	s.Run("listed locale with plural forms", func() {
+		_, err := ParseMessagesReader(strings.NewReader(`Items:
+  no_plural: [en]
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+`), "<stdin>", "", nil)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "locale en is listed in no_plural but has plural forms")
+	})
+}
+
+func (s *ParserTestSuite) TestParseMessagesBackend() {
+	results, err := ParseMessagesReader(strings.NewReader(`Hello:
+  backend: filesystem
+  en: "Hello"
+`), "<stdin>", "", nil)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Equal("filesystem", results[0].Backend)
+	s.NotContains(results[0].Templates, BackendKey)
+
+	_, err = ParseMessagesReader(strings.NewReader(`Hello:
+  backend: builtin
+  en: "Hello"
+`), "<stdin>", "", nil)
+	s.ErrorContains(err, `message "Hello": backend must be "go-i18n" or "filesystem"`)
+}
+
+func (s *ParserTestSuite) TestParseMessagesCountHint() {
+	results, err := ParseMessagesReader(strings.NewReader(`Items:
+  count_hint: "usually 1-100"
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+Hello:
+  en: "Hello"
+`), "<stdin>", "", nil)
+	s.Require().NoError(err)
+	s.Require().Len(results, 2)
+	s.Equal("usually 1-100", results[0].CountHint)
+	s.NotContains(results[0].Templates, CountHintKey)
+	s.Empty(FindMissingCountHints(results))
+
+	results[0].CountHint = ""
+	s.Equal([]string{`message "Items" in <stdin>:1`}, FindMissingCountHints(results))
+
+	_, err = ParseMessagesReader(strings.NewReader(`Items:
+  count_hint: 100
+  en: "{{.Count}} items"
+`), "<stdin>", "", nil)
+	s.ErrorContains(err, `message "Items": count_hint must be a non-empty string`)
+}
+
+func (s *ParserTestSuite) TestFilterByTags() {
+	messages := []model.MessageSource{
+		{ID: "Welcome", Tags: []string{"email", "transactional"}},
+		{ID: "Banner", Tags: []string{"web"}},
+		{ID: "Plain"},
+	}
+
+	s.Equal(messages, FilterByTags(messages, nil))
+	s.Equal(messages[:1], FilterByTags(messages, []string{"transactional"}))
+	s.Equal(messages[:2], FilterByTags(messages, []string{"web", "email"}))
+	s.Empty(FilterByTags(messages, []string{"push"}))
+}
+
+func (s *ParserTestSuite) TestParseMessagesVariants() {
+	s.Run("variants are split from translations", func() {
+		results, err := ParseMessagesReader(strings.NewReader(`Greeting:
+  en: "Hello {{.name}}"
+  variants:
+    b:
+      en: "Hi {{.name}}"
+    a:
+      en: "Hey {{.name}}"
+`), "<stdin>", "", nil)
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal(map[string]string{"en": "Hello {{.name}}"}, results[0].Templates)
+		s.Require().Len(results[0].Variants, 2)
+		s.Equal("a", results[0].Variants[0].Name)
+		s.Equal(map[string]string{"en": "Hey {{.name}}"}, results[0].Variants[0].Templates)
+		s.Equal("b", results[0].Variants[1].Name)
+	})
+
+	invalid := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"placeholder not in message", `Greeting:
+  en: "Hello {{.name}}"
+  variants:
+    b:
+      en: "Hi {{.user}}"
+`, `variant "b" (locale: en) uses {{.user}}, which message "Greeting" does not have`},
+		{"locale without template", `Greeting:
+  en: "Hello"
+  variants:
+    b:
+      ja: "やあ"
+`, `variant "b" for locale ja has no template`},
+		{"invalid name", `Greeting:
+  en: "Hello"
+  variants:
+    b.c:
+      en: "Hi"
+`, `variant "b.c" must only contain letters, digits and underscores`},
+	}
+	for _, tc := range invalid {
+		s.Run(tc.name, func() {
+			_, err := ParseMessagesReader(strings.NewReader(tc.content), "<stdin>", "", nil)
+			s.Require().Error(err)
+			s.Contains(err.Error(), tc.want)
+		})
+	}
+}
+
 func (s *ParserTestSuite) TestDecodeMessageFileErrors() {
 	// Create invalid YAML file
 	invalidFile := filepath.Join(s.tempDir, "invalid.yaml")
@@ -216,7 +1080,7 @@ func (s *ParserTestSuite) TestDecodeMessageFileErrors() {
 
 	// Verify that error is returned
 	pattern := filepath.Join(s.tempDir, "invalid.yaml")
-	results, err := ParseMessages(pattern)
+	results, err := ParseMessages(pattern, "", nil)
 	s.Error(err, "Verify that error is returned for invalid YAML files")
 	s.Nil(results)
 }
@@ -278,6 +1142,156 @@ product:
 	s.Equal("User", results[0].Items["user"]["en"])
 }
 
+func (s *ParserTestSuite) TestParsePlaceholdersMixedFormats() {
+	dir := s.T().TempDir()
+	files := map[string]string{
+		"entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+item:
+  ja: "商品"
+  en:
+    one: "item"
+    other: "items"`,
+		"field.ja.yaml": `FirstName: "名前"`,
+		"field.en.yaml": `FirstName: "First Name"`,
+	}
+	for name, content := range files {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	// Either configured format is tried first; files in the other format are detected
+	for _, compound := range []bool{true, false} {
+		results, err := ParsePlaceholders(filepath.Join(dir, "*.yaml"), []string{"ja", "en"}, compound)
+		s.Require().NoError(err, "compound: %v", compound)
+		s.Require().Len(results, 2)
+
+		byKind := map[string]model.PlaceholderSource{}
+		for _, result := range results {
+			byKind[result.Kind] = result
+		}
+		s.Equal("ユーザー", byKind["entity"].Items["user"]["ja"])
+		s.Equal("items", byKind["entity"].PluralItems["item"]["en"]["other"])
+		s.Equal(map[string]string{"ja": "名前", "en": "First Name"}, byKind["field"].Items["FirstName"])
+	}
+}
+
+func (s *ParserTestSuite) TestParsePlaceholdersMixedFormatErrors() {
+	dir := s.T().TempDir()
+
+	// Without a locale in its name, a file is only read as compound
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "field.yaml"), []byte(`FirstName: "First Name"`), 0644))
+	_, err := ParsePlaceholders(filepath.Join(dir, "field.yaml"), []string{"en"}, true)
+	s.ErrorContains(err, "failed to parse compound placeholder file")
+
+	// Invalid plural forms of a compound file are reported, not retried as simple
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "item.yaml"), []byte("item:\n  en:\n    lots: items\n"), 0644))
+	_, err = ParsePlaceholders(filepath.Join(dir, "item.yaml"), []string{"en"}, false)
+	s.ErrorContains(err, "failed to parse compound placeholder file")
+}
+
+func (s *ParserTestSuite) TestValidatePlaceholderLocales() {
+	dir := s.T().TempDir()
+	write := func(name, content string) {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("entity.yaml", "user:\n  en: \"User\"\n") // compound items may omit locales
+	write("field.en.yaml", "FirstName: \"First Name\"\nLastName: \"Last Name\"\n")
+	write("field.ja.yaml", "FirstName: \"名前\"\nLastName: \"苗字\"\n")
+
+	results, err := ParsePlaceholders(filepath.Join(dir, "*.yaml"), []string{"en", "ja"}, false)
+	s.Require().NoError(err)
+	s.NoError(ValidatePlaceholderLocales(results, []string{"en", "ja"}))
+
+	err = ValidatePlaceholderLocales(results, []string{"en", "ja", "fr"})
+	s.ErrorContains(err, `placeholder "field" item "FirstName"`)
+	s.ErrorContains(err, `has no value for locale "fr": add it to field.fr.yaml`)
+
+	err = ValidatePlaceholderLocales(results, []string{"en"})
+	s.ErrorContains(err, `has a value for locale "ja", which is not one of the configured locales [en]`)
+}
+
+func (s *ParserTestSuite) TestFindPlaceholderCycles() {
+	placeholders := []model.PlaceholderSource{
+		{
+			Kind: "entity",
+			Items: map[string]map[string]string{
+				"looping": {"ja": "{{.looping}}", "en": "see {{.back}}"},
+				"back":    {"en": "back to {{.looping}}"},
+				"account": {"en": "{{.deleted}} account"},
+			},
+			ItemLocations: map[string]model.SourceLocation{
+				"looping": {File: "entity.yaml", Line: 1},
+				"back":    {File: "entity.yaml", Line: 4},
+				"account": {File: "entity.yaml", Line: 6},
+			},
+		},
+		{
+			Kind:          "state",
+			Items:         map[string]map[string]string{"deleted": {"en": "deleted"}},
+			PluralItems:   map[string]map[string]map[string]string{"deleted": {"en": {"other": "{{.account}}s"}}},
+			ItemLocations: map[string]model.SourceLocation{"deleted": {File: "state.yaml", Line: 1}},
+		},
+	}
+
+	s.Equal([]string{
+		`placeholder item "account" in entity.yaml:6 (account → deleted → account)`,
+		`placeholder item "back" in entity.yaml:4 (back → looping → back)`,
+		`placeholder item "looping" in entity.yaml:1 (looping → looping)`,
+	}, FindPlaceholderCycles(placeholders))
+
+	delete(placeholders[0].Items, "looping")
+	delete(placeholders[1].PluralItems, "deleted")
+	s.Empty(FindPlaceholderCycles(placeholders))
+}
+
+func (s *ParserTestSuite) TestFindTemplateSyntax() {
+	placeholders := []model.PlaceholderSource{
+		{
+			Kind: "promotion",
+			Items: map[string]map[string]string{
+				"sale":     {"en": "100% {{discount}}", "ja": "100%割引"},
+				"account":  {"en": "{{.deleted}} account"},
+				"unknown":  {"en": "see {{.missing}}"},
+				"brackets": {"en": "closing }}"},
+			},
+			ItemLocations: map[string]model.SourceLocation{
+				"sale":     {File: "promotion.yaml", Line: 1},
+				"account":  {File: "promotion.yaml", Line: 4},
+				"unknown":  {File: "promotion.yaml", Line: 6},
+				"brackets": {File: "promotion.yaml", Line: 8},
+			},
+		},
+		{
+			Kind:          "state",
+			Items:         map[string]map[string]string{"deleted": {"en": "deleted"}},
+			PluralItems:   map[string]map[string]map[string]string{"deleted": {"en": {"one": "{{ .Count }} deleted", "other": "deleted"}}},
+			ItemLocations: map[string]model.SourceLocation{"deleted": {File: "state.yaml", Line: 1}},
+		},
+	}
+
+	s.Equal([]string{
+		`placeholder item "brackets" (en) in promotion.yaml:8: "closing }}"`,
+		`placeholder item "deleted" (en) in state.yaml:1: "{{ .Count }} deleted"`,
+		`placeholder item "sale" (en) in promotion.yaml:1: "100% {{discount}}"`,
+		`placeholder item "unknown" (en) in promotion.yaml:6: "see {{.missing}}"`,
+	}, FindTemplateSyntax(placeholders))
+
+	delete(placeholders[0].Items, "sale")
+	delete(placeholders[0].Items, "unknown")
+	delete(placeholders[0].Items, "brackets")
+	delete(placeholders[1].PluralItems, "deleted")
+	s.Empty(FindTemplateSyntax(placeholders))
+}
+
+func (s *ParserTestSuite) TestParsePlaceholdersSimpleFileWithoutLocale() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "field.yaml"), []byte(`FirstName: "First Name"`), 0644))
+
+	_, err := ParsePlaceholders(filepath.Join(dir, "*.yaml"), []string{"en"}, false)
+	s.ErrorContains(err, "has no locale in its name: name it like field.<locale>.yaml")
+}
+
 func (s *ParserTestSuite) TestParsePlaceholdersErrorCases() {
 	tests := []struct {
 		name        string
@@ -609,6 +1623,50 @@ item2: "Simple Item 2"`
 	s.Equal("Simple Item 2", result2["item2"])
 }
 
+func (s *ParserTestSuite) TestKeepLocales() {
+	messages := KeepLocales([]model.MessageSource{{
+		ID:           "Items",
+		Templates:    map[string]string{"ja": "アイテム", "en": "Items", DefaultLocale: "Items"},
+		RawTemplates: map[string]interface{}{"ja": "アイテム", "en": map[string]interface{}{"one": "Item", "other": "Items"}},
+		NoPlural:     []string{"ja", "ko"},
+		Variants: []model.MessageVariant{{
+			Name:      "short",
+			Templates: map[string]string{"ja": "品", "en": "Item"},
+		}},
+	}}, []string{"ja"})
+
+	s.Equal(map[string]string{"ja": "アイテム", DefaultLocale: "Items"}, messages[0].Templates)
+	s.Equal(map[string]interface{}{"ja": "アイテム"}, messages[0].RawTemplates)
+	s.Equal([]string{"ja"}, messages[0].NoPlural)
+	s.Equal(map[string]string{"ja": "品"}, messages[0].Variants[0].Templates)
+
+	placeholders := KeepPlaceholderLocales([]model.PlaceholderSource{{
+		Kind:  "entity",
+		Items: map[string]map[string]string{"user": {"ja": "ユーザー", "en": "User"}},
+	}}, []string{"en"})
+	s.Equal(map[string]string{"en": "User"}, placeholders[0].Items["user"])
+}
+
+func (s *ParserTestSuite) TestFindPlaceholderKindsWithoutLocale() {
+	placeholders := []model.PlaceholderSource{
+		{
+			Kind:          "entity",
+			Items:         map[string]map[string]string{"user": {"ja": "ユーザー", "en": "User"}, "item": {"ja": "アイテム"}},
+			ItemOrder:     []string{"user", "item"},
+			ItemLocations: map[string]model.SourceLocation{"user": {File: "entity.yaml", Line: 1}},
+		},
+		{
+			Kind:          "field",
+			Items:         map[string]map[string]string{"name": {"ja": "名前"}},
+			ItemLocations: map[string]model.SourceLocation{"name": {File: "field.ja.yaml", Line: 1}},
+		},
+		{Kind: "reason", Items: map[string]map[string]string{"free": {}}},
+	}
+
+	s.Equal([]string{`placeholder "field" (field.ja.yaml:1)`}, FindPlaceholderKindsWithoutLocale(placeholders, "en"))
+	s.Empty(FindPlaceholderKindsWithoutLocale(placeholders, "ja"))
+}
+
 // Run the test suite
 func TestParserSuite(t *testing.T) {
 	suite.Run(t, new(ParserTestSuite))
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// topLevelKeys returns the keys of the top-level mapping in the order they appear in the source,
+// along with the line on which each key is first defined.
+// Go maps lose this information, so it is recovered separately to support source-ordered
+// generation and tracing.
+func topLevelKeys(content []byte, ext string) ([]string, map[string]int, error) {
+	var keys []string
+	lines := map[string]int{}
+	if ext == jsonExt {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+			return nil, nil, fmt.Errorf("expected a JSON object at top level")
+		}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("unexpected JSON key %v", keyTok)
+			}
+			keys = append(keys, key)
+			if _, seen := lines[key]; !seen {
+				lines[key] = lineAt(content, dec.InputOffset())
+			}
+
+			// Skip the value
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, nil, err
+			}
+		}
+		return dedupeKeys(keys), lines, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, lines, nil
+	}
+	mapping := doc.Content[0]
+	for i := 0; i < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		keys = append(keys, key.Value)
+		if _, seen := lines[key.Value]; !seen {
+			lines[key.Value] = key.Line
+		}
+	}
+	return dedupeKeys(keys), lines, nil
+}
+
+// lineAt returns the 1-based line number of the given byte offset
+func lineAt(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+// dedupeKeys removes repeated keys keeping their first occurrence
+func dedupeKeys(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, key)
+	}
+	return result
+}
+
+// orderedIDs returns the IDs of a decoded map following the source order, with any IDs
+// missing from the order appended alphabetically so iteration is always deterministic
+func orderedIDs[V any](data map[string]V, order []string) []string {
+	ids := make([]string, 0, len(data))
+	seen := make(map[string]bool, len(data))
+	for _, id := range order {
+		if _, ok := data[id]; ok && !seen[id] {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+	var rest []string
+	for id := range data {
+		if !seen[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	return append(ids, rest...)
+}
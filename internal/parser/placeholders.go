@@ -1,14 +1,19 @@
 package parser
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/hacomono-lib/go-i18ngen/internal/model"
+	"github.com/hacomono-lib/go-i18ngen/internal/utils"
 
 	"gopkg.in/yaml.v3"
 )
@@ -39,6 +44,12 @@ func isValidGoIdentifier(name string) bool {
 }
 
 func ParsePlaceholders(pattern string, locales []string, compound bool) ([]model.PlaceholderSource, error) {
+	return ParsePlaceholdersFormat(pattern, locales, compound, "")
+}
+
+// ParsePlaceholdersFormat parses the placeholder files matching pattern like ParsePlaceholders,
+// decoding every file as inputFormat ("yaml" or "json") regardless of its extension when set
+func ParsePlaceholdersFormat(pattern string, locales []string, compound bool, inputFormat string) ([]model.PlaceholderSource, error) {
 	files, err := filepath.Glob(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid glob pattern for placeholders %q: %w", pattern, err)
@@ -49,52 +60,82 @@ func ParsePlaceholders(pattern string, locales []string, compound bool) ([]model
 		return []model.PlaceholderSource{}, nil
 	}
 
-	kindMap := map[string]map[string]map[string]string{} // kind -> id -> locale -> value
+	kindMap := map[string]map[string]map[string]string{}              // kind -> id -> locale -> value
+	var kindOrder []string                                            // kinds in order of first appearance
+	itemOrder := map[string][]string{}                                // kind -> item IDs in order of first appearance
+	descriptions := map[string]string{}                               // kind -> file comment
+	itemDescriptions := map[string]map[string]string{}                // kind -> id -> comment
+	itemLocations := map[string]map[string]model.SourceLocation{}     // kind -> id -> first definition
+	pluralMap := map[string]map[string]map[string]map[string]string{} // kind -> id -> locale -> form -> value
+	simpleKinds := map[string]bool{}                                  // kinds with simple-format files
 
 	for _, file := range files {
 		base := filepath.Base(file)
 		kind := strings.Split(base, ".")[0]
-		ext := filepath.Ext(file)
+		ext := formatExt(file, inputFormat)
 
-		f, err := os.Open(file) // #nosec G304 - Opening placeholder files is intentional
+		content, err := os.ReadFile(file) // #nosec G304 - Reading placeholder files is intentional
 		if err != nil {
-			return nil, fmt.Errorf("failed to open placeholder file %q: %w", file, err)
+			return nil, fmt.Errorf("failed to read placeholder file %q: %w", file, err)
 		}
-		defer func() { _ = f.Close() }()
 
-		var parsed map[string]map[string]string
-		if compound {
-			parsed, err = decodeCompoundFile(f, ext)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse compound placeholder file %q (ext: %s): %w", file, ext, err)
-			}
-		} else {
-			simple, err := decodeSimpleFile(f, ext)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse simple placeholder file %q (ext: %s, locale: %s): %w", file, ext, detectLocale(base), err)
-			}
-			parsed = make(map[string]map[string]string)
-			for k, v := range simple {
-				parsed[k] = map[string]string{detectLocale(base): v}
-			}
+		parsed, plurals, simple, err := decodePlaceholderFile(content, file, ext, compound)
+		if err != nil {
+			return nil, err
+		}
+		if simple {
+			simpleKinds[kind] = true
 		}
 
 		if _, ok := kindMap[kind]; !ok {
 			kindMap[kind] = map[string]map[string]string{}
+			kindOrder = append(kindOrder, kind)
 		}
 
-		for id, locMap := range parsed {
+		// Key order and comments are best-effort; decoding errors were already reported above
+		keys, lines, _ := topLevelKeys(content, ext)
+		fileComment, keyComments := sourceComments(content, ext)
+		if descriptions[kind] == "" {
+			descriptions[kind] = fileComment
+		}
+		if itemDescriptions[kind] == nil {
+			itemDescriptions[kind] = map[string]string{}
+		}
+		for id, comment := range keyComments {
+			if itemDescriptions[kind][id] == "" {
+				itemDescriptions[kind][id] = comment
+			}
+		}
+
+		if itemLocations[kind] == nil {
+			itemLocations[kind] = map[string]model.SourceLocation{}
+		}
+
+		for _, id := range orderedIDs(parsed, keys) {
+			locMap := parsed[id]
 			if _, ok := kindMap[kind][id]; !ok {
 				kindMap[kind][id] = map[string]string{}
+				itemOrder[kind] = append(itemOrder[kind], id)
+				itemLocations[kind][id] = model.SourceLocation{File: file, Line: lines[id]}
 			}
 			for locale, val := range locMap {
 				kindMap[kind][id][locale] = val
 			}
+			for locale, forms := range plurals[id] {
+				if pluralMap[kind] == nil {
+					pluralMap[kind] = map[string]map[string]map[string]string{}
+				}
+				if pluralMap[kind][id] == nil {
+					pluralMap[kind][id] = map[string]map[string]string{}
+				}
+				pluralMap[kind][id][locale] = forms
+			}
 		}
 	}
 
 	var results []model.PlaceholderSource
-	for kind, items := range kindMap {
+	for _, kind := range kindOrder {
+		items := kindMap[kind]
 		// Validate placeholder kind name
 		if !isValidGoIdentifier(kind) {
 			return nil, fmt.Errorf("invalid placeholder kind name %q: must be a valid Go identifier (pattern: ^[a-zA-Z_][a-zA-Z0-9_]*$)", kind)
@@ -110,13 +151,276 @@ func ParsePlaceholders(pattern string, locales []string, compound bool) ([]model
 		}
 
 		results = append(results, model.PlaceholderSource{
-			Kind:  kind,
-			Items: items,
+			Kind:             kind,
+			Items:            items,
+			Position:         len(results),
+			ItemOrder:        itemOrder[kind],
+			Description:      descriptions[kind],
+			ItemDescriptions: itemDescriptions[kind],
+			ItemLocations:    itemLocations[kind],
+			PluralItems:      pluralMap[kind],
+			SimpleFormat:     simpleKinds[kind],
 		})
 	}
 	return results, nil
 }
 
+// decodePlaceholderFile decodes a placeholder file in the configured format and, when that fails,
+// in the other one, so compound files (entity.yaml) and simple per-locale files (field.en.yaml)
+// can be mixed. Simple files must name their locale; simple reports whether the file was one.
+func decodePlaceholderFile(
+	content []byte, file, ext string, compound bool,
+) (parsed map[string]map[string]string, plurals map[string]map[string]map[string]string, simple bool, err error) {
+	base := filepath.Base(file)
+	locale, hasLocale := fileLocale(base)
+
+	var raw map[string]map[string]interface{}
+	if compound {
+		raw, err = decodeCompoundFile(bytes.NewReader(content), ext)
+		if err != nil {
+			if hasLocale {
+				if values, simpleErr := decodeSimpleFile(bytes.NewReader(content), ext); simpleErr == nil {
+					return simpleValues(values, locale), nil, true, nil
+				}
+			}
+			return nil, nil, false, fmt.Errorf("failed to parse compound placeholder file %q (ext: %s): %w", file, ext, err)
+		}
+	} else {
+		values, simpleErr := decodeSimpleFile(bytes.NewReader(content), ext)
+		if simpleErr == nil {
+			if !hasLocale {
+				return nil, nil, false, fmt.Errorf(
+					"simple placeholder file %q has no locale in its name: name it like %s.<locale>%s",
+					file, strings.Split(base, ".")[0], ext)
+			}
+			return simpleValues(values, locale), nil, true, nil
+		}
+		var compoundErr error
+		raw, compoundErr = decodeCompoundFile(bytes.NewReader(content), ext)
+		if raw == nil || compoundErr != nil {
+			return nil, nil, false, fmt.Errorf(
+				"failed to parse simple placeholder file %q (ext: %s, locale: %s): %w", file, ext, detectLocale(base), simpleErr)
+		}
+	}
+
+	parsed, plurals, err = splitPluralValues(raw)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to parse compound placeholder file %q (ext: %s): %w", file, ext, err)
+	}
+	return parsed, plurals, false, nil
+}
+
+// KeepPlaceholderLocales drops the values of placeholder items in locales other than locales.
+// The placeholders are modified in place and returned.
+func KeepPlaceholderLocales(placeholders []model.PlaceholderSource, locales []string) []model.PlaceholderSource {
+	keep := func(locale string) bool { return slices.Contains(locales, locale) }
+	for _, ph := range placeholders {
+		for _, values := range ph.Items {
+			deleteLocales(values, keep)
+		}
+		for _, forms := range ph.PluralItems {
+			deleteLocales(forms, keep)
+		}
+		for _, autofilled := range ph.Autofilled {
+			deleteLocales(autofilled, keep)
+		}
+	}
+	return placeholders
+}
+
+// FindPlaceholderKindsWithoutLocale describes every placeholder kind with values in some locales
+// but none in locale, sorted. Without values such a kind would be generated as a Value type in
+// a package holding only locale, while the packages of the other locales get a Text type.
+func FindPlaceholderKindsWithoutLocale(placeholders []model.PlaceholderSource, locale string) []string {
+	var missing []string
+	for _, ph := range placeholders {
+		localized, found := false, false
+		for _, values := range ph.Items {
+			if len(values) > 0 {
+				localized = true
+			}
+			if _, ok := values[locale]; ok {
+				found = true
+				break
+			}
+		}
+		if localized && !found {
+			ids := orderedIDs(ph.Items, ph.ItemOrder)
+			missing = append(missing, fmt.Sprintf("placeholder %q (%s)", ph.Kind, ph.ItemLocations[ids[0]]))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// ValidatePlaceholderLocales ensures every item of a placeholder kind read from simple-format
+// files has a value in each configured locale and in no other one. Each locale of such a kind
+// lives in its own file, so a missing or misnamed file would otherwise silently drop values.
+func ValidatePlaceholderLocales(placeholders []model.PlaceholderSource, locales []string) error {
+	for _, ph := range placeholders {
+		if !ph.SimpleFormat {
+			continue
+		}
+		for _, id := range orderedIDs(ph.Items, ph.ItemOrder) {
+			values := ph.Items[id]
+			for _, locale := range locales {
+				if _, ok := values[locale]; !ok {
+					location := ph.ItemLocations[id]
+					return fmt.Errorf("placeholder %q item %q (%s) has no value for locale %q: add it to %s.%s%s",
+						ph.Kind, id, location, locale, ph.Kind, locale, filepath.Ext(location.File))
+				}
+			}
+			for _, locale := range sortedKeys(values) {
+				if !slices.Contains(locales, locale) {
+					return fmt.Errorf("placeholder %q item %q (%s) has a value for locale %q, which is not one of the configured locales %v",
+						ph.Kind, id, ph.ItemLocations[id], locale, locales)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FindPlaceholderCycles describes every cycle of placeholder items referencing each other in their
+// text, such as {{.back}} in looping and {{.looping}} in back, sorted, with the path of item IDs.
+// References are followed across kinds and locales, including plural forms. The generated code
+// leaves the reference closing a cycle as-is, so the text would render with template syntax.
+func FindPlaceholderCycles(placeholders []model.PlaceholderSource) []string {
+	locations := make(map[string]model.SourceLocation)
+	refs := make(map[string][]string) // item ID -> referenced item IDs, sorted
+	for _, ph := range placeholders {
+		for id := range ph.Items {
+			locations[id] = ph.ItemLocations[id]
+		}
+	}
+	for _, ph := range placeholders {
+		for id, values := range ph.Items {
+			texts := make([]string, 0, len(values))
+			for _, text := range values {
+				texts = append(texts, text)
+			}
+			for _, forms := range ph.PluralItems[id] {
+				for _, text := range forms {
+					texts = append(texts, text)
+				}
+			}
+			for _, text := range texts {
+				for _, match := range fieldPattern.FindAllStringSubmatch(text, -1) {
+					if _, exists := locations[match[1]]; exists && !slices.Contains(refs[id], match[1]) {
+						refs[id] = append(refs[id], match[1])
+					}
+				}
+			}
+			slices.Sort(refs[id])
+		}
+	}
+
+	var cycles []string
+	seen := make(map[string]bool) // cycle path starting at its smallest ID -> reported
+	done := make(map[string]bool) // item IDs whose references have all been followed
+	var path []string
+	var visit func(id string)
+	visit = func(id string) {
+		if start := slices.Index(path, id); start != -1 {
+			cycle := path[start:]
+			// Report each cycle once, starting at its smallest ID
+			first := slices.Index(cycle, slices.Min(cycle))
+			loop := append(append(slices.Clone(cycle[first:]), cycle[:first]...), cycle[first])
+			key := strings.Join(loop, " → ")
+			if !seen[key] {
+				seen[key] = true
+				cycles = append(cycles, fmt.Sprintf("placeholder item %q in %s (%s)", loop[0], locations[loop[0]], key))
+			}
+			return
+		}
+		if done[id] {
+			return
+		}
+		path = append(path, id)
+		for _, ref := range refs[id] {
+			visit(ref)
+		}
+		path = path[:len(path)-1]
+		done[id] = true
+	}
+	ids := make([]string, 0, len(refs))
+	for id := range refs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		visit(id)
+	}
+	slices.Sort(cycles)
+	return cycles
+}
+
+// FindTemplateSyntax describes every placeholder text containing template delimiters other than
+// references to existing items, such as {{discount}} or {{.missing}}, sorted. Placeholder values
+// are data inserted into messages as-is, so such text renders with the braces instead of as a template.
+func FindTemplateSyntax(placeholders []model.PlaceholderSource) []string {
+	items := make(map[string]bool)
+	for _, ph := range placeholders {
+		for id := range ph.Items {
+			items[id] = true
+		}
+	}
+	dropReference := func(ref string) string {
+		if items[fieldPattern.FindStringSubmatch(ref)[1]] {
+			return ""
+		}
+		return ref
+	}
+
+	var found []string
+	seen := make(map[string]bool)
+	report := func(ph model.PlaceholderSource, id, locale, text string) {
+		rest := fieldPattern.ReplaceAllStringFunc(text, dropReference)
+		if !strings.Contains(rest, "{{") && !strings.Contains(rest, "}}") {
+			return
+		}
+		entry := fmt.Sprintf("placeholder item %q (%s) in %s: %q", id, locale, ph.ItemLocations[id], text)
+		if !seen[entry] {
+			seen[entry] = true
+			found = append(found, entry)
+		}
+	}
+	for _, ph := range placeholders {
+		for id, values := range ph.Items {
+			for locale, text := range values {
+				report(ph, id, locale, text)
+			}
+		}
+		for id, locales := range ph.PluralItems {
+			for locale, forms := range locales {
+				for _, text := range forms {
+					report(ph, id, locale, text)
+				}
+			}
+		}
+	}
+	slices.Sort(found)
+	return found
+}
+
+// simpleValues converts the items of a simple-format file to the compound layout
+func simpleValues(simple map[string]string, locale string) map[string]map[string]string {
+	parsed := make(map[string]map[string]string, len(simple))
+	for k, v := range simple {
+		parsed[k] = map[string]string{locale: v}
+	}
+	return parsed
+}
+
+// fileLocale returns the locale of a simple-format file name such as field.en.yaml
+func fileLocale(filename string) (string, bool) {
+	parts := strings.Split(filename, ".")
+	if len(parts) < 3 || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
 func detectLocale(filename string) string {
 	parts := strings.Split(filename, ".")
 	if len(parts) >= 2 {
@@ -125,22 +429,71 @@ func detectLocale(filename string) string {
 	return "unknown"
 }
 
-func decodeCompoundFile(file *os.File, ext string) (map[string]map[string]string, error) {
-	var data map[string]map[string]string
+func decodeCompoundFile(r io.Reader, ext string) (map[string]map[string]interface{}, error) {
+	var data map[string]map[string]interface{}
 	if ext == jsonExt {
-		err := json.NewDecoder(file).Decode(&data)
+		err := json.NewDecoder(r).Decode(&data)
 		return data, err
 	}
-	err := yaml.NewDecoder(file).Decode(&data)
+	err := yaml.NewDecoder(r).Decode(&data)
 	return data, err
 }
 
-func decodeSimpleFile(file *os.File, ext string) (map[string]string, error) {
+// splitPluralValues separates plain placeholder values from plural ones ({one: ..., other: ...}).
+// Plural values also provide their "other" form (or the first form in CLDR order) as plain value,
+// which is used when no count is available.
+func splitPluralValues(
+	raw map[string]map[string]interface{},
+) (map[string]map[string]string, map[string]map[string]map[string]string, error) {
+	values := make(map[string]map[string]string, len(raw))
+	plurals := map[string]map[string]map[string]string{}
+
+	for id, locales := range raw {
+		values[id] = make(map[string]string, len(locales))
+		for locale, value := range locales {
+			switch v := value.(type) {
+			case map[string]interface{}:
+				if err := validatePluralForms(v); err != nil {
+					return nil, nil, fmt.Errorf("placeholder item %q (locale: %s): %w", id, locale, err)
+				}
+				forms := make(map[string]string, len(v))
+				for form, text := range v {
+					forms[form] = fmt.Sprintf("%v", text)
+				}
+				if plurals[id] == nil {
+					plurals[id] = map[string]map[string]string{}
+				}
+				plurals[id][locale] = forms
+				values[id][locale] = defaultPluralForm(forms)
+			case nil:
+				values[id][locale] = ""
+			default:
+				values[id][locale] = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+	return values, plurals, nil
+}
+
+// defaultPluralForm returns the "other" form, or the first available form in CLDR order
+func defaultPluralForm(forms map[string]string) string {
+	if text, ok := forms["other"]; ok {
+		return text
+	}
+	for _, category := range utils.PluralCategories {
+		if text, ok := forms[category]; ok {
+			return text
+		}
+	}
+	return ""
+}
+
+func decodeSimpleFile(r io.Reader, ext string) (map[string]string, error) {
 	var data map[string]string
 	if ext == jsonExt {
-		err := json.NewDecoder(file).Decode(&data)
+		err := json.NewDecoder(r).Decode(&data)
 		return data, err
 	}
-	err := yaml.NewDecoder(file).Decode(&data)
+	err := yaml.NewDecoder(r).Decode(&data)
 	return data, err
 }
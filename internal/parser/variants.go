@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+// VariantsKey holds alternate phrasings of a message, selected at runtime with WithVariant
+const VariantsKey = "variants"
+
+var variantNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// extractVariants removes the variants block of every message from data and returns it
+// as message ID -> variant name -> locale -> raw template
+func extractVariants(data map[string]map[string]interface{}) (map[string]map[string]map[string]interface{}, error) {
+	var result map[string]map[string]map[string]interface{}
+	for id, localeData := range data {
+		raw, ok := localeData[VariantsKey]
+		if !ok {
+			continue
+		}
+		delete(localeData, VariantsKey)
+
+		variants, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("message %q: %s must map variant names to localized templates", id, VariantsKey)
+		}
+		templates := make(map[string]map[string]interface{}, len(variants))
+		for name, rawLocales := range variants {
+			locales, ok := rawLocales.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("message %q: variant %q must map locales to templates", id, name)
+			}
+			templates[name] = locales
+		}
+		if result == nil {
+			result = make(map[string]map[string]map[string]interface{})
+		}
+		result[id] = templates
+	}
+	return result, nil
+}
+
+// buildVariants validates the variants of a message and converts them to model variants sorted by name.
+// Variants may only use locales and placeholders of the message itself, since they are rendered
+// with the same constructor arguments.
+func buildVariants(
+	id string,
+	variants map[string]map[string]interface{},
+	localeTemplates map[string]string,
+	suffixSeparator string,
+) ([]model.MessageVariant, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+
+	fields := make(map[string]bool)
+	for _, template := range localeTemplates {
+		for _, info := range ExtractFieldInfos(template, suffixSeparator) {
+			fields[info.String()] = true
+		}
+	}
+
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]model.MessageVariant, 0, len(names))
+	for _, name := range names {
+		if !variantNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("variant %q must only contain letters, digits and underscores", name)
+		}
+
+		rawTemplates := variants[name]
+		templates := convertMixedToStringMap(map[string]map[string]interface{}{name: rawTemplates})[name]
+		for locale, template := range templates {
+			if _, exists := localeTemplates[locale]; !exists {
+				return nil, fmt.Errorf("variant %q for locale %s has no template in message %q", name, locale, id)
+			}
+			if err := validatePluralForms(rawTemplates[locale]); err != nil {
+				return nil, fmt.Errorf("variant %q (locale: %s): %w", name, locale, err)
+			}
+			if err := validateTemplateComplexity(template); err != nil {
+				return nil, fmt.Errorf("variant %q (locale: %s): %w", name, locale, err)
+			}
+			for _, info := range ExtractFieldInfos(template, suffixSeparator) {
+				if !fields[info.String()] {
+					return nil, fmt.Errorf("variant %q (locale: %s) uses {{.%s}}, which message %q does not have",
+						name, locale, info.String(), id)
+				}
+			}
+		}
+
+		result = append(result, model.MessageVariant{
+			Name:         name,
+			Templates:    templates,
+			RawTemplates: rawTemplates,
+		})
+	}
+	return result, nil
+}
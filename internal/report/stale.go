@@ -0,0 +1,120 @@
+// Package report provides read-only reports over parsed messages to support translation workflows.
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+const (
+	// ReasonSourceChanged marks translations whose source text changed since the baseline was recorded
+	ReasonSourceChanged = "source changed"
+	// ReasonUntranslated marks translations identical to the source text
+	ReasonUntranslated = "untranslated"
+)
+
+// Baseline records a hash of the source text of each message at the time its translation was last updated
+type Baseline struct {
+	Source   string            `json:"source"`
+	Target   string            `json:"target"`
+	Messages map[string]string `json:"messages"` // message ID -> source text hash
+}
+
+// StaleEntry is a target translation that likely needs attention
+type StaleEntry struct {
+	ID     string
+	Reason string
+}
+
+// NewBaseline records the current source text of every message translated into target
+func NewBaseline(messages []model.MessageSource, source, target string) *Baseline {
+	baseline := &Baseline{Source: source, Target: target, Messages: map[string]string{}}
+	for _, msg := range messages {
+		sourceText, hasSource := msg.RawTemplates[source]
+		if _, hasTarget := msg.RawTemplates[target]; !hasSource || !hasTarget {
+			continue
+		}
+		baseline.Messages[msg.ID] = hashText(sourceText)
+	}
+	return baseline
+}
+
+// LoadBaseline reads a baseline file; a missing file yields an empty baseline
+func LoadBaseline(path, source, target string) (*Baseline, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - Reading the baseline file is intentional
+	if errors.Is(err, os.ErrNotExist) {
+		return &Baseline{Source: source, Target: target, Messages: map[string]string{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %q: %w", path, err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %q: %w", path, err)
+	}
+	if baseline.Source != source || baseline.Target != target {
+		return nil, fmt.Errorf("baseline %q compares %s to %s, not %s to %s",
+			path, baseline.Source, baseline.Target, source, target)
+	}
+	if baseline.Messages == nil {
+		baseline.Messages = map[string]string{}
+	}
+	return &baseline, nil
+}
+
+// Write stores the baseline as indented JSON
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write baseline %q: %w", path, err)
+	}
+	return nil
+}
+
+// Stale compares the target translations with the source locale and returns, sorted by ID,
+// the entries whose source text changed since the baseline or that equal the source verbatim.
+// Messages without a target translation are not reported.
+func Stale(messages []model.MessageSource, source, target string, baseline *Baseline) []StaleEntry {
+	var entries []StaleEntry
+	for _, msg := range messages {
+		sourceText, hasSource := msg.RawTemplates[source]
+		targetText, hasTarget := msg.RawTemplates[target]
+		if !hasSource || !hasTarget {
+			continue
+		}
+
+		sourceHash := hashText(sourceText)
+		switch {
+		case baseline != nil && baseline.Messages[msg.ID] != "" && baseline.Messages[msg.ID] != sourceHash:
+			entries = append(entries, StaleEntry{ID: msg.ID, Reason: ReasonSourceChanged})
+		case hashText(targetText) == sourceHash:
+			entries = append(entries, StaleEntry{ID: msg.ID, Reason: ReasonUntranslated})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// hashText hashes a raw template; plural forms are encoded with sorted keys so the hash is stable
+func hashText(raw interface{}) string {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", raw))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func messages(en map[string]interface{}, ja map[string]interface{}) []model.MessageSource {
+	var result []model.MessageSource
+	for id, text := range en {
+		raw := map[string]interface{}{"en": text}
+		if translated, ok := ja[id]; ok {
+			raw["ja"] = translated
+		}
+		result = append(result, model.MessageSource{ID: id, RawTemplates: raw})
+	}
+	return result
+}
+
+func TestStale(t *testing.T) {
+	ja := map[string]interface{}{
+		"Greeting": "こんにちは",
+		"Farewell": "Goodbye",
+		"UserCount": map[string]interface{}{
+			"other": "{{.Count}}人のユーザー",
+		},
+	}
+	original := messages(map[string]interface{}{
+		"Greeting":  "Hello",
+		"Farewell":  "Goodbye",
+		"Missing":   "Not translated yet",
+		"UserCount": map[string]interface{}{"one": "{{.Count}} user", "other": "{{.Count}} users"},
+	}, ja)
+
+	t.Run("without baseline reports untranslated entries", func(t *testing.T) {
+		assert.Equal(t, []StaleEntry{
+			{ID: "Farewell", Reason: ReasonUntranslated},
+		}, Stale(original, "en", "ja", nil))
+	})
+
+	t.Run("reports entries whose source changed since the baseline", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, NewBaseline(original, "en", "ja").Write(path))
+
+		baseline, err := LoadBaseline(path, "en", "ja")
+		require.NoError(t, err)
+
+		updated := messages(map[string]interface{}{
+			"Greeting":  "Hello there",
+			"Farewell":  "Goodbye",
+			"Missing":   "Not translated yet",
+			"UserCount": map[string]interface{}{"one": "{{.Count}} member", "other": "{{.Count}} members"},
+		}, ja)
+		assert.Equal(t, []StaleEntry{
+			{ID: "Farewell", Reason: ReasonUntranslated},
+			{ID: "Greeting", Reason: ReasonSourceChanged},
+			{ID: "UserCount", Reason: ReasonSourceChanged},
+		}, Stale(updated, "en", "ja", baseline))
+	})
+
+	t.Run("missing baseline file is empty", func(t *testing.T) {
+		baseline, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.json"), "en", "ja")
+		require.NoError(t, err)
+		assert.Empty(t, baseline.Messages)
+	})
+
+	t.Run("rejects baselines for other locales", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, NewBaseline(original, "en", "ja").Write(path))
+
+		_, err := LoadBaseline(path, "en", "fr")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "compares en to ja")
+	})
+}
@@ -0,0 +1,163 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+)
+
+// Stats summarizes the size of a corpus and which features its messages use
+type Stats struct {
+	Messages          int
+	SuffixMessages    int // Messages using suffix notation such as {{.entity:from}}
+	FunctionMessages  int // Messages calling template functions, piped or not
+	PluralMessages    int // Messages rendered with a plural count
+	VariantMessages   int // Messages declaring variants
+	PlaceholderValues int // Items across all placeholder kinds
+	PlaceholderKinds  []KindStats
+	LocaleCoverage    []LocaleStats
+	Tags              []TagStats
+}
+
+// KindStats is the number of items of a placeholder kind
+type KindStats struct {
+	Kind  string
+	Items int
+}
+
+// TagStats is the number of messages carrying a tag
+type TagStats struct {
+	Tag      string
+	Messages int
+}
+
+// LocaleStats is the number of messages translated into a locale. Texts copied by
+// autofill_from are not counted as translated.
+type LocaleStats struct {
+	Locale     string
+	Translated int
+}
+
+// ComputeStats counts the messages and placeholders of a corpus; locales lists the configured
+// locales in the order coverage is reported, and defs provides the plural support of each message
+func ComputeStats(
+	messages []model.MessageSource,
+	placeholders []model.PlaceholderSource,
+	defs *model.Definitions,
+	locales []string,
+) Stats {
+	stats := Stats{Messages: len(messages)}
+
+	translated := make(map[string]int, len(locales))
+	tagged := make(map[string]int)
+	for _, msg := range messages {
+		for _, tag := range msg.Tags {
+			tagged[tag]++
+		}
+		for _, info := range msg.FieldInfos {
+			if info.Suffix != "" {
+				stats.SuffixMessages++
+				break
+			}
+		}
+		if callsFunctions(msg) {
+			stats.FunctionMessages++
+		}
+		if len(msg.Variants) > 0 {
+			stats.VariantMessages++
+		}
+		for _, locale := range locales {
+			if _, ok := msg.RawTemplates[locale]; ok && msg.Autofilled[locale] == "" {
+				translated[locale]++
+			}
+		}
+	}
+
+	if defs != nil {
+		for _, msg := range defs.Messages {
+			if msg.SupportsCount {
+				stats.PluralMessages++
+			}
+		}
+	}
+
+	for _, ph := range placeholders {
+		stats.PlaceholderKinds = append(stats.PlaceholderKinds, KindStats{Kind: ph.Kind, Items: len(ph.Items)})
+		stats.PlaceholderValues += len(ph.Items)
+	}
+	for _, locale := range locales {
+		stats.LocaleCoverage = append(stats.LocaleCoverage, LocaleStats{Locale: locale, Translated: translated[locale]})
+	}
+
+	tags := make([]string, 0, len(tagged))
+	for tag := range tagged {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		stats.Tags = append(stats.Tags, TagStats{Tag: tag, Messages: tagged[tag]})
+	}
+	return stats
+}
+
+// callsFunctions reports whether any template of msg, including plural forms and variants, calls a function
+func callsFunctions(msg model.MessageSource) bool {
+	rawTemplates := []map[string]interface{}{msg.RawTemplates}
+	for _, variant := range msg.Variants {
+		rawTemplates = append(rawTemplates, variant.RawTemplates)
+	}
+	for _, byLocale := range rawTemplates {
+		for _, raw := range byLocale {
+			switch v := raw.(type) {
+			case string:
+				if model.CallsTemplateFunctions(v) {
+					return true
+				}
+			case map[string]interface{}:
+				for _, form := range v {
+					if text, ok := form.(string); ok && model.CallsTemplateFunctions(text) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Write prints the statistics as a compact table
+func (s Stats) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Messages\t%d\n", s.Messages)
+	fmt.Fprintf(tw, "  with suffix notation\t%d\n", s.SuffixMessages)
+	fmt.Fprintf(tw, "  with template functions\t%d\n", s.FunctionMessages)
+	fmt.Fprintf(tw, "  plural\t%d\n", s.PluralMessages)
+	fmt.Fprintf(tw, "  with variants\t%d\n", s.VariantMessages)
+
+	fmt.Fprintf(tw, "Placeholders\t%d\n", s.PlaceholderValues)
+	for _, kind := range s.PlaceholderKinds {
+		fmt.Fprintf(tw, "  %s\t%d\n", kind.Kind, kind.Items)
+	}
+
+	fmt.Fprintln(tw, "Locale coverage")
+	for _, locale := range s.LocaleCoverage {
+		percent := 100.0
+		if s.Messages > 0 {
+			percent = float64(locale.Translated) * 100 / float64(s.Messages)
+		}
+		fmt.Fprintf(tw, "  %s\t%d/%d\t%.1f%%\n", locale.Locale, locale.Translated, s.Messages, percent)
+	}
+
+	if len(s.Tags) > 0 {
+		fmt.Fprintln(tw, "Tags")
+		for _, tag := range s.Tags {
+			fmt.Fprintf(tw, "  %s\t%d\n", tag.Tag, tag.Messages)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/model"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStats(t *testing.T) {
+	messages := []model.MessageSource{
+		{
+			ID:           "Moved",
+			RawTemplates: map[string]interface{}{"en": "{{.entity:from}} to {{.entity:to}}", "ja": "{{.entity:from}}から{{.entity:to}}へ"},
+			FieldInfos:   []model.FieldInfo{{Name: "entity", Suffix: "from"}, {Name: "entity", Suffix: "to"}},
+		},
+		{
+			ID:           "Welcome",
+			RawTemplates: map[string]interface{}{"en": "Welcome {{.name | title}}", "ja": "Welcome {{.name}}"},
+			Autofilled:   map[string]string{"ja": "en"},
+			Tags:         []string{"onboarding", "email"},
+		},
+		{
+			ID: "Items",
+			RawTemplates: map[string]interface{}{
+				"en": map[string]interface{}{"one": "{{.Count}} item", "other": `{{.Count}} item{{locale "en" "s"}}`},
+			},
+			Variants: []model.MessageVariant{{Name: "short", RawTemplates: map[string]interface{}{"en": "{{.Count}}"}}},
+			Tags:     []string{"email"},
+		},
+	}
+	placeholders := []model.PlaceholderSource{
+		{Kind: "entity", Items: map[string]map[string]string{"user": {}, "file": {}}},
+		{Kind: "field", Items: map[string]map[string]string{"FirstName": {}}},
+	}
+	defs := &model.Definitions{Messages: []templatex.Message{{ID: "Items", SupportsCount: true}, {ID: "Moved"}}}
+
+	stats := ComputeStats(messages, placeholders, defs, []string{"en", "ja"})
+	assert.Equal(t, 3, stats.Messages)
+	assert.Equal(t, 1, stats.SuffixMessages)
+	assert.Equal(t, 2, stats.FunctionMessages)
+	assert.Equal(t, 1, stats.PluralMessages)
+	assert.Equal(t, 1, stats.VariantMessages)
+	assert.Equal(t, 3, stats.PlaceholderValues)
+	assert.Equal(t, []KindStats{{Kind: "entity", Items: 2}, {Kind: "field", Items: 1}}, stats.PlaceholderKinds)
+	// The autofilled Japanese text of Welcome is not a translation
+	assert.Equal(t, []LocaleStats{{Locale: "en", Translated: 3}, {Locale: "ja", Translated: 1}}, stats.LocaleCoverage)
+	assert.Equal(t, []TagStats{{Tag: "email", Messages: 2}, {Tag: "onboarding", Messages: 1}}, stats.Tags)
+
+	var out strings.Builder
+	require.NoError(t, stats.Write(&out))
+	assert.Equal(t, `Messages                   3
+  with suffix notation     1
+  with template functions  2
+  plural                   1
+  with variants            1
+Placeholders               3
+  entity                   2
+  field                    1
+Locale coverage
+  en  3/3  100.0%
+  ja  1/3  33.3%
+Tags
+  email       2
+  onboarding  1
+`, out.String())
+}
@@ -0,0 +1,69 @@
+package templatex
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// PlaceholderDataFileName is the file holding the placeholder texts embedded with EmbedData
+	PlaceholderDataFileName = "i18n.gen.placeholders.yaml"
+	// dataFileHeader marks data files as generated so clean may remove them
+	dataFileHeader = "# Code generated by i18ngen. DO NOT EDIT.\n"
+)
+
+// MessageDataFileName returns the file holding the go-i18n messages of locale, embedded with EmbedData
+// or loaded at runtime with ExternalData
+func MessageDataFileName(locale string) string {
+	return "i18n.gen.messages." + locale + ".yaml"
+}
+
+// MessageDataFile returns the name of the message data file of locale for the template
+func (d TemplateDef) MessageDataFile(locale string) string {
+	return MessageDataFileName(locale)
+}
+
+// PlaceholderDataFile returns the name of the placeholder data file for the template
+func (d TemplateDef) PlaceholderDataFile() string {
+	return PlaceholderDataFileName
+}
+
+// DataFiles returns the files, keyed by name, that code generated with EmbedData embeds instead
+// of inline data: one go-i18n message file per locale of messagesByLocale and the placeholder texts.
+// The message files are also what code generated with ExternalData loads at runtime.
+func DataFiles(messagesByLocale map[string]map[string]string, placeholders []PlaceholderTemplate) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(messagesByLocale)+1)
+	for locale, messages := range messagesByLocale {
+		ids := make([]string, 0, len(messages))
+		for id := range messages {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		var buf bytes.Buffer
+		buf.WriteString(dataFileHeader)
+		for _, id := range ids {
+			buf.WriteString(id + ":" + messages[id] + "\n")
+		}
+		files[MessageDataFileName(locale)] = buf.Bytes()
+	}
+
+	texts := make(map[string]map[string]string)
+	for _, ph := range placeholders {
+		if !ph.HasLocaleFiles {
+			continue
+		}
+		for id, localeTexts := range ph.LocaleTemplates {
+			texts[id] = localeTexts
+		}
+	}
+	encoded, err := yaml.Marshal(texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode placeholder data: %w", err)
+	}
+	files[PlaceholderDataFileName] = append([]byte(dataFileHeader), encoded...)
+	return files, nil
+}
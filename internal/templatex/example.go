@@ -0,0 +1,138 @@
+package templatex
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed usage_example.gotmpl
+var usageExampleTemplateContent string
+
+//go:embed smoke_test.gotmpl
+var smokeTestTemplateContent string
+
+const (
+	// UsageExampleFileName is the name of the usage example written next to the generated package
+	UsageExampleFileName = "usage_example.go"
+	// SmokeTestFileName is the name of the smoke test written into the generated package
+	SmokeTestFileName = "i18n_gen_test.go"
+)
+
+// UsageExampleDef is the data for the usage example template
+type UsageExampleDef struct {
+	PackageName   string
+	ImportPath    string
+	PrimaryLocale string
+	// BuildConstraint matches the constraint of the generated package so the example builds with it
+	BuildConstraint string
+	Calls           []ExampleCall
+}
+
+// ExampleCall is a constructor call demonstrating a generated message
+type ExampleCall struct {
+	StructName string
+	Expr       string
+}
+
+// RenderUsageExample writes a usage example in its own package, importing the generated package
+// from importPath and calling each message constructor with sample placeholder values
+func RenderUsageExample(outPath, pkg, importPath, primaryLocale, buildConstraint string, placeholderDefs []Placeholder, messageDefs []Message) error {
+	code, err := RenderTemplateWithConfig(usageExampleTemplateContent, UsageExampleDef{
+		PackageName:     pkg,
+		ImportPath:      importPath,
+		PrimaryLocale:   primaryLocale,
+		BuildConstraint: buildConstraint,
+		Calls:           exampleCalls(pkg, placeholderDefs, messageDefs),
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPath), 0750); err != nil {
+		return fmt.Errorf("failed to create usage example directory: %w", err)
+	}
+	if err := os.WriteFile(outPath, code, 0600); err != nil {
+		return fmt.Errorf("failed to write usage example: %w", err)
+	}
+	return nil
+}
+
+// RenderSmokeTest writes a test into the generated package that constructs each message with
+// sample placeholder values, as in the usage example, and checks it renders in primaryLocale
+func RenderSmokeTest(outPath, pkg, primaryLocale, buildConstraint string, placeholderDefs []Placeholder, messageDefs []Message) error {
+	code, err := RenderTemplateWithConfig(smokeTestTemplateContent, UsageExampleDef{
+		PackageName:     pkg,
+		PrimaryLocale:   primaryLocale,
+		BuildConstraint: buildConstraint,
+		Calls:           exampleCalls("", placeholderDefs, messageDefs),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, code, 0600); err != nil {
+		return fmt.Errorf("failed to write smoke test: %w", err)
+	}
+	return nil
+}
+
+// exampleCalls returns a constructor call with sample placeholder values for each message,
+// qualified with pkg unless pkg is empty
+func exampleCalls(pkg string, placeholderDefs []Placeholder, messageDefs []Message) []ExampleCall {
+	placeholders := make(map[string]Placeholder, len(placeholderDefs))
+	for _, ph := range placeholderDefs {
+		placeholders[ph.StructName] = ph
+	}
+	calls := make([]ExampleCall, 0, len(messageDefs))
+	for _, msg := range messageDefs {
+		calls = append(calls, ExampleCall{StructName: msg.StructName, Expr: exampleCall(pkg, msg, placeholders)})
+	}
+	return calls
+}
+
+// exampleCall returns a constructor call of msg with sample placeholder values, qualified with pkg
+// unless pkg is empty
+func exampleCall(pkg string, msg Message, placeholders map[string]Placeholder) string {
+	args := make([]string, 0, len(msg.Fields))
+	for _, field := range msg.Fields {
+		args = append(args, exampleArgument(pkg, field, placeholders[field.Type]))
+	}
+	expr := fmt.Sprintf("%sNew%s(%s)", qualifier(pkg), msg.StructName, strings.Join(args, ", "))
+	if msg.SupportsCount {
+		expr += ".WithPluralCount(2)"
+	}
+	return expr
+}
+
+// qualifier returns the prefix referring to a name of package pkg, or "" inside the package itself
+func qualifier(pkg string) string {
+	if pkg == "" {
+		return ""
+	}
+	return pkg + "."
+}
+
+// exampleArgument returns sample data for a message field: a predefined instance for
+// localized placeholders, preferring the item named like the field, or the field name as a value
+func exampleArgument(pkg string, field Field, ph Placeholder) string {
+	if field.PlainValue {
+		return fmt.Sprintf("%q", field.TemplateKey)
+	}
+	if ph.IsValue || len(ph.Items) == 0 {
+		return fmt.Sprintf("%sNew%s(%q)", qualifier(pkg), field.Type, field.TemplateKey)
+	}
+	if ph.IsEnum {
+		return qualifier(pkg) + ph.Items[0].ConstName
+	}
+
+	item := ph.Items[0]
+	for _, candidate := range ph.Items {
+		if candidate.ID == field.TemplateKey {
+			item = candidate
+			break
+		}
+	}
+	return fmt.Sprintf("%s%ss.%s", qualifier(pkg), ph.StructName, item.FieldName)
+}
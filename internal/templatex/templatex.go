@@ -24,26 +24,87 @@ type Message struct {
 	Templates         map[string]string      // locale -> template (simplified for processing)
 	RawTemplates      map[string]interface{} // locale -> raw template data (preserves plural forms)
 	SupportsCount     bool
-	PluralPlaceholder string // The actual plural placeholder key used (e.g., "Count", "Quantity")
+	PluralPlaceholder string                       // The actual plural placeholder key used (e.g., "Count", "Quantity")
+	ParamsType        string                       // Named-field struct accepted by NewXFromParams; empty unless enabled for the field count
+	Autofilled        map[string]string            // locale -> source locale of a template copied by autofill_from
+	RuntimeFields     []RuntimeField               // Placeholders resolved by runtime providers, not constructor arguments
+	Metadata          map[string]map[string]string // locale -> go-i18n message hint (e.g. leftDelim) -> value
+	Variants          []MessageVariant             // Alternate phrasings selected with WithVariant
+	UsesFuncs         bool                         // Some template calls locale or a custom template function
+	Markdown          bool                         // Localize renders markdown to HTML and escapes placeholder values
+	ItemRefs          []ItemRef                    // Placeholder items fixed in the template, e.g. {{entity "user"}}
+	Backend           string                       // Backend declared by the message; empty follows the backend option
+	CountHint         string                       // Expected magnitude of the count, shown in the godoc
+}
+
+// ItemRef is a placeholder item referenced by ID in a message template and rendered without a constructor argument
+type ItemRef struct {
+	TemplateKey string // Template data key the reference was rewritten to (e.g. "entity__user")
+	Type        string // Placeholder type of the item (e.g. "EntityText")
+	ID          string // Item ID (e.g. "user")
+	Plural      bool   // The placeholder type has plural forms selected by the message count
+}
+
+// MessageVariant is an alternate phrasing of a message, rendered as a separate go-i18n message
+type MessageVariant struct {
+	Name         string                 // Name passed to WithVariant (e.g. "b")
+	ID           string                 // go-i18n message ID of the variant (e.g. "Greeting.b")
+	Templates    map[string]string      // locale -> template, with placeholders processed like the message
+	RawTemplates map[string]interface{} // locale -> raw template data (preserves plural forms)
+	UsesFuncs    bool                   // Some template calls locale or a custom template function
+}
+
+// RuntimeField is a message placeholder whose value comes from a runtime provider
+type RuntimeField struct {
+	TemplateKey string
+	Provider    string
+}
+
+// RuntimePlaceholder is a placeholder declared with runtime: true, rendered through a provider variable
+type RuntimePlaceholder struct {
+	Name     string // Placeholder name used in templates (e.g. "appName")
+	Provider string // Generated provider variable (e.g. "AppNameProvider")
+}
+
+// LocaleInfo is the display information of a configured locale, generated into the LocaleInfo map
+type LocaleInfo struct {
+	Locale     string // Locale tag (e.g. "ja")
+	Name       string // English name (e.g. "Japanese")
+	NativeName string // Name in the language itself (e.g. "日本語")
+	RTL        bool   // The script is written right to left
 }
 
 type Field struct {
 	FieldName   string
 	Type        string
 	TemplateKey string
+	Plural      bool // The placeholder type has plural forms selected by the message count
+	PlainValue  bool // The field is a plain string rather than a placeholder type
+	Value       bool // The placeholder type is a value type created from a string with New<Type>
+	Enum        bool // The placeholder type is an enum created from a string with Parse<Type>
 }
 
 type Placeholder struct {
-	StructName string
-	VarName    string
-	IsValue    bool
-	Items      []PlaceholderItem
+	Kind        string // Source kind, taken from the placeholder file name
+	StructName  string
+	VarName     string
+	IsValue     bool
+	Items       []PlaceholderItem
+	Description string // Translator-facing comment from the source file
+	HasPlural   bool   // At least one item has plural forms
+	IDType      string // Typed item ID with one constant per item; empty unless emit_placeholder_consts
+	IsEnum      bool   // Items are the allowed values of an enum placeholder, exposed through ConstName
+	IDsFunc     string // Function listing the sorted item IDs, e.g. EntityIDs; empty for value placeholders
 }
 
 type PlaceholderItem struct {
-	ID        string
-	FieldName string
-	Templates map[string]string // locale -> localized value
+	ID          string
+	FieldName   string
+	Templates   map[string]string            // locale -> localized value
+	Description string                       // Translator-facing comment from the source file
+	PluralForms map[string]map[string]string // locale -> plural form -> localized value
+	ConstName   string                       // Exported constant holding the ID, when IDType is set
+	Autofilled  map[string]string            // locale -> source locale of a value copied by autofill_from
 }
 
 type MessageTemplate struct {
@@ -66,56 +127,233 @@ type TemplateDef struct {
 	MessageDefs      []Message
 	Locales          []string
 	MessagesByLocale map[string]map[string]string
+	Config           TemplateConfig
+}
+
+// FuncMessageIDs returns the go-i18n message IDs, including variants, whose templates
+// call locale or a custom template function
+func (d TemplateDef) FuncMessageIDs() []string {
+	var ids []string
+	for _, msg := range d.MessageDefs {
+		if msg.UsesFuncs {
+			ids = append(ids, msg.ID)
+		}
+		for _, variant := range msg.Variants {
+			if variant.UsesFuncs {
+				ids = append(ids, variant.ID)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// HasVariants reports whether any message declares variants
+func (d TemplateDef) HasVariants() bool {
+	for _, msg := range d.MessageDefs {
+		if len(msg.Variants) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ExampleCall returns a constructor call of msg with sample values from the corpus, for its godoc
+func (d TemplateDef) ExampleCall(msg Message) string {
+	placeholders := make(map[string]Placeholder, len(d.PlaceholderDefs))
+	for _, ph := range d.PlaceholderDefs {
+		placeholders[ph.StructName] = ph
+	}
+	return exampleCall("", msg, placeholders)
+}
+
+// PlaceholderKinds returns the names of the text and enum placeholder kinds, such as Entity, in sorted order
+func (d TemplateDef) PlaceholderKinds() []string {
+	var kinds []string
+	for _, ph := range d.PlaceholderDefs {
+		if ph.IDsFunc != "" {
+			kinds = append(kinds, utils.ToCamelCase(ph.Kind))
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// HasMarkdown reports whether any message is rendered from markdown to HTML
+func (d TemplateDef) HasMarkdown() bool {
+	for _, msg := range d.MessageDefs {
+		if msg.Markdown {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPluralPlaceholders reports whether any placeholder item has plural forms
+func (d TemplateDef) HasPluralPlaceholders() bool {
+	for _, ph := range d.PlaceholderDefs {
+		if ph.HasPlural {
+			return true
+		}
+	}
+	return false
 }
 
 // TemplateConfig represents configuration for template generation
 type TemplateConfig struct {
-	// Future configuration options can be added here
+	// FilesystemLoader generates LoadTranslations/WatchTranslations to load messages at runtime
+	FilesystemLoader bool
+	// TranslationsDir is the default directory loaded at startup by the filesystem loader
+	TranslationsDir string
+	// Localizer generates a Localizer type bound to a locale for dependency injection
+	Localizer bool
+	// BuildConstraint is emitted as a //go:build line above the package clause when set
+	BuildConstraint string
+	// RuntimePlaceholders generates a provider variable for each placeholder resolved at runtime
+	RuntimePlaceholders []RuntimePlaceholder
+	// Coverage generates AllMessageIDs and MessageCoverage for translation completeness checks
+	Coverage bool
+	// LocalizeByID generates LocalizeByID, NewLocalizableByID and ValidateParams for rendering
+	// messages by ID from a parameter map
+	LocalizeByID bool
+	// EmbedData embeds message and placeholder data from the files returned by DataFiles
+	// instead of inlining it in the generated code
+	EmbedData bool
+	// ExternalData leaves messages out of the generated code; they are loaded at runtime
+	// from the message files returned by DataFiles
+	ExternalData bool
+	// ImplementError generates an Error method on every message type so messages can be returned as errors
+	ImplementError bool
+	// CustomFuncs generates a CustomFuncs hook with a stub for each function listed in template_functions
+	CustomFuncs []string
+	// EmbeddedMessages are the go-i18n message IDs, including variants, always rendered from the
+	// embedded data: translations loaded at runtime by the filesystem loader do not override them
+	EmbeddedMessages []string
+	// LocaleInfo generates a LocaleInfo map with these entries for language pickers; nil omits it
+	LocaleInfo []LocaleInfo
+	// CountType is the Go type of the plural count set with WithPluralCount; empty means int
+	CountType string
+	// InputsDigest is recorded in the header with --if-stale or --since-git, so the next run can
+	// tell the output was generated from the current inputs; empty omits the line
+	InputsDigest string
+}
+
+// PluralCountType returns the Go type of the plural count of messages
+func (c TemplateConfig) PluralCountType() string {
+	if c.CountType == "" {
+		return "int"
+	}
+	return c.CountType
 }
 
 // Helper functions
 
 // convertRawTemplateToYaml converts a raw template (which may be string or map) to YAML format
 func convertRawTemplateToYaml(rawTemplate interface{}) string {
-	switch v := rawTemplate.(type) {
-	case string:
+	if v, ok := rawTemplate.(string); ok {
 		// Simple string template - wrap in quotes and add space
 		return " \"" + strings.ReplaceAll(v, "\"", "\\\"") + "\""
-	case map[string]interface{}:
+	}
+	if forms, ok := rawPluralForms(rawTemplate); ok {
 		// Plural forms map (e.g., {"one": "...", "other": "..."})
 		// Convert to YAML block format for go-i18n
-		var parts []string
-		for form, template := range v {
-			if tmpl, ok := template.(string); ok {
-				parts = append(parts, fmt.Sprintf("%s: %q", form, tmpl))
-			}
-		}
-		sort.Strings(parts) // Consistent ordering
+		parts := pluralFormLines(forms)
 		if len(parts) > 0 {
 			// For plural forms in go-i18n YAML format
 			return "\n  " + strings.Join(parts, "\n  ")
 		}
 		return ""
-	case map[interface{}]interface{}:
-		// Handle YAML-parsed format
-		var parts []string
-		for k, v := range v {
-			if form, ok := k.(string); ok {
-				if tmpl, ok := v.(string); ok {
-					parts = append(parts, fmt.Sprintf("%s: %q", form, tmpl))
-				}
-			}
+	}
+	// Unknown type, convert to string
+	return fmt.Sprintf("%v", rawTemplate)
+}
+
+// hasPluralTemplate reports whether any locale of rawTemplates has plural forms
+func hasPluralTemplate(rawTemplates map[string]interface{}) bool {
+	for _, raw := range rawTemplates {
+		if _, isPlural := rawPluralForms(raw); isPlural {
+			return true
 		}
-		sort.Strings(parts)
-		if len(parts) > 0 {
-			// For plural forms in go-i18n YAML format
-			return "\n  " + strings.Join(parts, "\n  ")
+	}
+	return false
+}
+
+// messageText returns the processed template of locale, or the raw text when there is none
+func messageText(rawTemplate interface{}, templates map[string]string, locale string) string {
+	if processed, exists := templates[locale]; exists {
+		return processed
+	}
+	return fmt.Sprintf("%v", rawTemplate)
+}
+
+// everyPluralForm returns plural forms with text in every CLDR category
+func everyPluralForm(text string) map[string]interface{} {
+	forms := make(map[string]interface{}, len(utils.PluralCategories))
+	for _, category := range utils.PluralCategories {
+		forms[category] = text
+	}
+	return forms
+}
+
+// messageFragment renders the YAML fragment of a message in one locale, keeping plural forms
+// of the raw template and otherwise preferring the processed template with suffix notation converted
+func messageFragment(rawTemplate interface{}, templates map[string]string, locale string) string {
+	if _, isPlural := rawPluralForms(rawTemplate); isPlural {
+		return convertRawTemplateToYaml(rawTemplate)
+	}
+	if processedTemplate, exists := templates[locale]; exists {
+		return convertRawTemplateToYaml(processedTemplate)
+	}
+	return convertRawTemplateToYaml(rawTemplate)
+}
+
+// messageWithMetadata renders a message as a go-i18n YAML block carrying metadata hints
+// (e.g. leftDelim) next to its plural forms; a singular template becomes the "other" form
+func messageWithMetadata(rawTemplate interface{}, template string, metadata map[string]string) string {
+	forms, isPlural := rawPluralForms(rawTemplate)
+	if !isPlural {
+		forms = map[string]string{"other": template}
+	}
+	lines := pluralFormLines(forms)
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %q", key, metadata[key]))
+	}
+	return "\n  " + strings.Join(lines, "\n  ")
+}
+
+// pluralFormLines renders plural forms as YAML lines in CLDR order (zero, one, two, few, many, other),
+// keeping every category so go-i18n can select it; other keys follow alphabetically
+func pluralFormLines(forms map[string]string) []string {
+	keys := make([]string, 0, len(forms))
+	for form := range forms {
+		keys = append(keys, form)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		rankI, rankJ := pluralFormRank(keys[i]), pluralFormRank(keys[j])
+		if rankI != rankJ {
+			return rankI < rankJ
 		}
-		return ""
-	default:
-		// Unknown type, convert to string
-		return fmt.Sprintf("%v", v)
+		return keys[i] < keys[j]
+	})
+
+	lines := make([]string, 0, len(keys))
+	for _, form := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %q", form, forms[form]))
+	}
+	return lines
+}
+
+func pluralFormRank(form string) int {
+	if index := utils.PluralCategoryIndex(form); index != -1 {
+		return index
 	}
+	return len(utils.PluralCategories)
 }
 
 // findMessageDef finds a MessageDef by ID
@@ -159,13 +397,21 @@ func capitalizeFunc(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
-func commentSafeFunc(s string) string {
+// commentSafeFunc continues multi-line text as line comments so it can follow "// " in a template.
+// Continuation lines start with prefix, which defaults to "// " and should repeat the indentation
+// and comment marker of the first line, e.g. {{commentSafe .Description "\t// "}} inside a struct.
+func commentSafeFunc(s string, prefix ...string) string {
 	// Properly format multi-line strings as comments
 	lines := strings.Split(s, "\n")
 	if len(lines) <= 1 {
 		return s
 	}
 
+	continuation := "// "
+	if len(prefix) > 0 {
+		continuation = prefix[0]
+	}
+
 	// For multi-line strings, properly convert newlines to comment format
 	var result []string
 	for i, line := range lines {
@@ -173,8 +419,8 @@ func commentSafeFunc(s string) string {
 		if i == 0 {
 			result = append(result, trimmed)
 		} else {
-			// Add proper indentation and comment prefix for lines after the first
-			result = append(result, "//         "+trimmed)
+			// Repeat the comment prefix of the first line for lines after it
+			result = append(result, strings.TrimRight(continuation+trimmed, " \t"))
 		}
 	}
 	return strings.Join(result, "\n")
@@ -189,6 +435,16 @@ func sortLocalesFunc(templates map[string]string) []string {
 	return locales
 }
 
+// sortedIDsFunc returns the source IDs of items in sorted order, whatever order the items are generated in
+func sortedIDsFunc(items []PlaceholderItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 func sortMapKeysFunc(m map[string]map[string]string) []string {
 	var keys []string
 	for key := range m {
@@ -257,6 +513,32 @@ func formatInterfaceMap(t map[interface{}]interface{}) string {
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
+// rawPluralForms returns the plural forms of a raw template.
+// Plural blocks decode either as map[string]interface{} or, depending on the YAML decoder
+// and the target type, as map[interface{}]interface{}; both shapes are accepted, and
+// non-string keys or texts are dropped.
+func rawPluralForms(rawTemplate interface{}) (map[string]string, bool) {
+	forms := map[string]string{}
+	switch v := rawTemplate.(type) {
+	case map[string]interface{}:
+		for form, template := range v {
+			if tmpl, ok := template.(string); ok {
+				forms[form] = tmpl
+			}
+		}
+	case map[interface{}]interface{}:
+		for key, template := range v {
+			form, isString := key.(string)
+			if tmpl, ok := template.(string); ok && isString {
+				forms[form] = tmpl
+			}
+		}
+	default:
+		return nil, false
+	}
+	return forms, true
+}
+
 // CreateFuncMap creates the template function map used for rendering
 func CreateFuncMap() template.FuncMap {
 	return template.FuncMap{
@@ -269,6 +551,7 @@ func CreateFuncMap() template.FuncMap {
 		"lastKey":              lastKeyFunc,
 		"formatPluralTemplate": formatPluralTemplateFunc,
 		"safeIdent":            utils.SafeGoIdentifier,
+		"sortedIDs":            sortedIDsFunc,
 	}
 }
 
@@ -315,7 +598,52 @@ func RenderGoI18nWithConfig(
 	locales []string,
 	config *TemplateConfig,
 ) error {
-	// Build message data by locale for go-i18n
+	code, err := GenerateGoI18nWithConfig(pkg, primaryLocale, messages, placeholders, placeholderDefs, messageDefs, locales, config)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outPath, code, 0600); err != nil {
+		return fmt.Errorf("failed to write generated code to file %q: %w", outPath, err)
+	}
+
+	return nil
+}
+
+// GenerateGoI18nWithConfig renders the go-i18n generated code without writing it to a file
+func GenerateGoI18nWithConfig(
+	pkg, primaryLocale string,
+	messages []MessageTemplate,
+	placeholders []PlaceholderTemplate,
+	placeholderDefs []Placeholder,
+	messageDefs []Message,
+	locales []string,
+	config *TemplateConfig,
+) ([]byte, error) {
+	messagesByLocale := BuildMessagesByLocale(messages, messageDefs, locales)
+
+	if config == nil {
+		config = &TemplateConfig{}
+	}
+
+	return RenderTemplateWithConfig(goI18nTemplateContent, TemplateDef{
+		PackageName:      pkg,
+		PrimaryLocale:    primaryLocale,
+		Messages:         messages,
+		Placeholders:     placeholders,
+		PlaceholderDefs:  placeholderDefs,
+		MessageDefs:      messageDefs,
+		Locales:          locales,
+		MessagesByLocale: messagesByLocale,
+		Config:           *config,
+	}, config)
+}
+
+// BuildMessagesByLocale builds go-i18n message data keyed by locale and message ID.
+// Values are YAML fragments that follow the message ID key, preserving plural forms.
+// The result is only ever accessed by key or ranged over by text/template, which visits map keys
+// in sorted order, so the rendered output does not depend on map iteration order.
+func BuildMessagesByLocale(messages []MessageTemplate, messageDefs []Message, locales []string) map[string]map[string]string {
 	messagesByLocale := make(map[string]map[string]string)
 	for _, locale := range locales {
 		messagesByLocale[locale] = make(map[string]string)
@@ -324,24 +652,20 @@ func RenderGoI18nWithConfig(
 	// Use both RawTemplates (for plural forms) and processed Templates (for suffix notation)
 	for _, msgDef := range messageDefs {
 		if msgDef.RawTemplates != nil {
-			// Check if this is a plural message by looking for map structures
+			plural := hasPluralTemplate(msgDef.RawTemplates)
 			for locale, rawTemplate := range msgDef.RawTemplates {
 				if messagesByLocale[locale] == nil {
 					messagesByLocale[locale] = make(map[string]string)
 				}
+				// A single text in a plural message is rendered for every count, so in locales with
+				// plural rules it fills every form and go-i18n never looks up a form that is missing
+				if _, isPlural := rawPluralForms(rawTemplate); plural && !isPlural && utils.HasPluralForms(locale) {
+					rawTemplate = everyPluralForm(messageText(rawTemplate, msgDef.Templates, locale))
+				}
 
-				// If rawTemplate is a map, it's a plural form - use it directly
-				switch rawTemplate.(type) {
-				case map[string]interface{}, map[interface{}]interface{}:
-					messagesByLocale[locale][msgDef.ID] = convertRawTemplateToYaml(rawTemplate)
-				default:
-					// For non-plural templates, use processed Templates to get suffix notation conversion
-					if processedTemplate, exists := msgDef.Templates[locale]; exists {
-						messagesByLocale[locale][msgDef.ID] = convertRawTemplateToYaml(processedTemplate)
-					} else {
-						// Fallback to raw template if processed version not available
-						messagesByLocale[locale][msgDef.ID] = convertRawTemplateToYaml(rawTemplate)
-					}
+				messagesByLocale[locale][msgDef.ID] = messageFragment(rawTemplate, msgDef.Templates, locale)
+				if metadata := msgDef.Metadata[locale]; len(metadata) > 0 {
+					messagesByLocale[locale][msgDef.ID] = messageWithMetadata(rawTemplate, msgDef.Templates[locale], metadata)
 				}
 			}
 		} else {
@@ -355,6 +679,25 @@ func RenderGoI18nWithConfig(
 		}
 	}
 
+	// Variants are separate go-i18n messages; locales without a translation of the variant
+	// get the default text so selecting a variant never fails to render
+	for _, msgDef := range messageDefs {
+		for _, variant := range msgDef.Variants {
+			for locale, rawTemplate := range variant.RawTemplates {
+				if messagesByLocale[locale] == nil {
+					messagesByLocale[locale] = make(map[string]string)
+				}
+				messagesByLocale[locale][variant.ID] = messageFragment(rawTemplate, variant.Templates, locale)
+			}
+			for _, localeMessages := range messagesByLocale {
+				fragment, exists := localeMessages[msgDef.ID]
+				if _, translated := localeMessages[variant.ID]; exists && !translated {
+					localeMessages[variant.ID] = fragment
+				}
+			}
+		}
+	}
+
 	// Also add any messages that don't have MessageDef equivalent
 	for _, msg := range messages {
 		if msgDef := findMessageDef(messageDefs, msg.ID); msgDef == nil {
@@ -367,23 +710,20 @@ func RenderGoI18nWithConfig(
 		}
 	}
 
-	code, err := RenderTemplateWithConfig(goI18nTemplateContent, TemplateDef{
-		PackageName:      pkg,
-		PrimaryLocale:    primaryLocale,
-		Messages:         messages,
-		Placeholders:     placeholders,
-		PlaceholderDefs:  placeholderDefs,
-		MessageDefs:      messageDefs,
-		Locales:          locales,
-		MessagesByLocale: messagesByLocale,
-	}, config)
-	if err != nil {
-		return err
-	}
+	return messagesByLocale
+}
 
-	if err := os.WriteFile(outPath, code, 0600); err != nil {
-		return fmt.Errorf("failed to write generated code to file %q: %w", outPath, err)
+// FormatMessageFile renders the messages of a single locale as a go-i18n YAML message file
+func FormatMessageFile(messages map[string]string) []byte {
+	ids := make([]string, 0, len(messages))
+	for id := range messages {
+		ids = append(ids, id)
 	}
+	sort.Strings(ids)
 
-	return nil
+	var buf bytes.Buffer
+	for _, id := range ids {
+		buf.WriteString(id + ":" + messages[id] + "\n")
+	}
+	return buf.Bytes()
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
 )
 
 type TemplatexTestSuite struct {
@@ -102,6 +103,201 @@ func (s *TemplatexTestSuite) TestRenderGoI18n_Success() {
 	s.Assert().Contains(contentStr, "package testpkg")
 	s.Assert().Contains(contentStr, "UserWelcome")
 	s.Assert().Contains(contentStr, "NewUserWelcome")
+	s.Assert().Contains(contentStr, "func (p NameText) ID() string")
+	s.Assert().Contains(contentStr, `NameTexts = struct`)
+	s.Assert().Contains(contentStr, `User: NameText{id: "user"}`)
+	s.Assert().Contains(contentStr, "var _ Localizable = NameText{}")
+	s.Assert().Contains(contentStr, "var _ Localizable = UserWelcome{}")
+}
+
+func (s *TemplatexTestSuite) TestExampleCall() {
+	def := TemplateDef{
+		PlaceholderDefs: []Placeholder{
+			{StructName: "EntityText", Items: []PlaceholderItem{
+				{ID: "product", FieldName: "Product"},
+				{ID: "user", FieldName: "User"},
+			}},
+			{StructName: "Status", IsEnum: true, Items: []PlaceholderItem{{ID: "done", ConstName: "StatusDone"}}},
+			{StructName: "AmountValue", IsValue: true, Items: []PlaceholderItem{{ID: "amount", FieldName: "Amount"}}},
+		},
+	}
+
+	msg := Message{
+		StructName: "UserCharged",
+		Fields: []Field{
+			{FieldName: "Entity", Type: "EntityText", TemplateKey: "entity"},
+			{FieldName: "User", Type: "EntityText", TemplateKey: "user"},
+			{FieldName: "Status", Type: "Status", TemplateKey: "status"},
+			{FieldName: "Amount", Type: "AmountValue", TemplateKey: "amount"},
+			{FieldName: "Note", Type: "string", TemplateKey: "note", PlainValue: true},
+		},
+	}
+	s.Equal(`NewUserCharged(EntityTexts.Product, EntityTexts.User, StatusDone, NewAmountValue("amount"), "note")`, def.ExampleCall(msg))
+
+	msg = Message{StructName: "ItemCount", SupportsCount: true}
+	s.Equal("NewItemCount().WithPluralCount(2)", def.ExampleCall(msg))
+}
+
+func (s *TemplatexTestSuite) TestRenderSmokeTest() {
+	outPath := filepath.Join(s.tempDir, SmokeTestFileName)
+	err := RenderSmokeTest(outPath, "i18n", "en", "prod",
+		[]Placeholder{{StructName: "EntityText", Items: []PlaceholderItem{{ID: "user", FieldName: "User"}}}},
+		[]Message{
+			{StructName: "EntityNotFound", Fields: []Field{{FieldName: "Entity", Type: "EntityText", TemplateKey: "entity"}}},
+			{StructName: "Welcome"},
+		})
+	s.Require().NoError(err)
+
+	content, err := os.ReadFile(outPath)
+	s.Require().NoError(err)
+	code := string(content)
+	s.Contains(code, "// Code generated by i18ngen. DO NOT EDIT.")
+	s.Contains(code, "//go:build prod")
+	s.Contains(code, "package i18n")
+	s.Contains(code, `{"EntityNotFound", NewEntityNotFound(EntityTexts.User)},`)
+	s.Contains(code, `{"Welcome", NewWelcome()},`)
+	s.Contains(code, `tt.msg.Localize("en")`)
+}
+
+func (s *TemplatexTestSuite) TestRenderGoI18n_PlaceholderDescriptions() {
+	outputFile := filepath.Join(s.tempDir, "descriptions.go")
+
+	placeholders := []Placeholder{
+		{
+			StructName:  "EntityText",
+			VarName:     "entityTemplates",
+			Description: "Entities referenced in error messages\nand notifications",
+			Items: []PlaceholderItem{
+				{
+					ID:          "member",
+					FieldName:   "Member",
+					Templates:   map[string]string{"en": "Member"},
+					Description: "Someone invited to a workspace\nwho has not signed up yet",
+				},
+				{ID: "user", FieldName: "User", Templates: map[string]string{"en": "User"}},
+			},
+		},
+	}
+
+	err := RenderGoI18n(outputFile, "testpkg", "en", nil, nil, placeholders, nil, []string{"en"})
+	s.Require().NoError(err)
+
+	content, err := os.ReadFile(outputFile)
+	s.Require().NoError(err)
+
+	contentStr := string(content)
+	s.Contains(contentStr, "// Entities referenced in error messages\n// and notifications\n")
+	// Continuation lines of multi-line descriptions stay aligned with the first line
+	s.Contains(contentStr, "\t// Someone invited to a workspace\n\t// who has not signed up yet\n")
+}
+
+func (s *TemplatexTestSuite) TestGenerateGoI18n_PluralShapes() {
+	forms := map[string]interface{}{"one": "{{.Count}} item", "other": "{{.Count}} items"}
+	shapes := map[string]interface{}{
+		"string keys": forms,
+		"interface keys": map[interface{}]interface{}{
+			"other": "{{.Count}} items",
+			"one":   "{{.Count}} item",
+			1:       "dropped: non-string key",
+		},
+	}
+
+	for name, raw := range shapes {
+		s.Run(name, func() {
+			messages := []Message{{
+				ID:            "ItemCount",
+				StructName:    "ItemCount",
+				Templates:     map[string]string{"en": "{{.Count}} items"},
+				RawTemplates:  map[string]interface{}{"en": raw},
+				SupportsCount: true,
+			}}
+
+			code, err := GenerateGoI18nWithConfig("testpkg", "en", nil, nil, nil, messages, []string{"en"}, nil)
+			s.Require().NoError(err)
+			s.Contains(string(code), "ItemCount:\n  one: \"{{.Count}} item\"\n  other: \"{{.Count}} items\"\n")
+			s.NotContains(string(code), "dropped")
+		})
+	}
+}
+
+func (s *TemplatexTestSuite) TestGenerateGoI18n_Metadata() {
+	messages := []Message{
+		{
+			ID:           "Braces",
+			StructName:   "Braces",
+			Templates:    map[string]string{"en": "Use {{ and }}"},
+			RawTemplates: map[string]interface{}{"en": "Use {{ and }}"},
+			Metadata:     map[string]map[string]string{"en": {"rightDelim": ">>", "leftDelim": "<<"}},
+		},
+		{
+			ID:            "ItemCount",
+			StructName:    "ItemCount",
+			Templates:     map[string]string{"en": "{{.Count}} items"},
+			RawTemplates:  map[string]interface{}{"en": map[string]interface{}{"one": "{{.Count}} item", "other": "{{.Count}} items"}},
+			SupportsCount: true,
+			Metadata:      map[string]map[string]string{"en": {"description": "Cart size"}},
+		},
+	}
+
+	code, err := GenerateGoI18nWithConfig("testpkg", "en", nil, nil, nil, messages, []string{"en"}, nil)
+	s.Require().NoError(err)
+	s.Contains(string(code), "Braces:\n  other: \"Use {{ and }}\"\n  leftDelim: \"<<\"\n  rightDelim: \">>\"\n")
+	s.Contains(string(code), "ItemCount:\n  one: \"{{.Count}} item\"\n  other: \"{{.Count}} items\"\n  description: \"Cart size\"\n")
+}
+
+func (s *TemplatexTestSuite) TestDataFiles() {
+	files, err := DataFiles(
+		map[string]map[string]string{
+			"en": {"Welcome": ` "Hello {{.name}}"`, "Bye": ` "Bye"`},
+		},
+		[]PlaceholderTemplate{
+			{Name: "entity", HasLocaleFiles: true, LocaleTemplates: map[string]map[string]string{
+				"user": {"en": `User "admin"`},
+			}},
+			{Name: "name", HasLocaleFiles: false},
+		},
+	)
+	s.Require().NoError(err)
+	s.Len(files, 2)
+	s.Equal("# Code generated by i18ngen. DO NOT EDIT.\nBye: \"Bye\"\nWelcome: \"Hello {{.name}}\"\n",
+		string(files["i18n.gen.messages.en.yaml"]))
+
+	var placeholders map[string]map[string]string
+	s.Require().NoError(yaml.Unmarshal(files[PlaceholderDataFileName], &placeholders))
+	s.Equal(map[string]map[string]string{"user": {"en": `User "admin"`}}, placeholders)
+}
+
+func (s *TemplatexTestSuite) TestRawPluralForms() {
+	forms, ok := rawPluralForms(map[interface{}]interface{}{"one": "item", "other": "items", 2: "two", "few": 3})
+	s.True(ok)
+	s.Equal(map[string]string{"one": "item", "other": "items"}, forms)
+
+	forms, ok = rawPluralForms(map[string]interface{}{"other": "items"})
+	s.True(ok)
+	s.Equal(map[string]string{"other": "items"}, forms)
+
+	_, ok = rawPluralForms("items")
+	s.False(ok)
+}
+
+func (s *TemplatexTestSuite) TestConvertRawTemplateToYamlOrdersPluralForms() {
+	// Texts sort opposite to the categories, so ordering by "form: text" would differ
+	fragment := convertRawTemplateToYaml(map[string]interface{}{
+		"other": "a items",
+		"many":  "b items",
+		"few":   "c items",
+		"two":   "d items",
+		"one":   "e item",
+		"zero":  "f items",
+	})
+	s.Equal("\n  zero: \"f items\"\n  one: \"e item\"\n  two: \"d items\"\n  few: \"c items\"\n  many: \"b items\"\n  other: \"a items\"", fragment)
+
+	// Every call emits the same order
+	for i := 0; i < 10; i++ {
+		s.Equal(fragment, convertRawTemplateToYaml(map[string]interface{}{
+			"one": "e item", "few": "c items", "zero": "f items", "other": "a items", "two": "d items", "many": "b items",
+		}))
+	}
 }
 
 func (s *TemplatexTestSuite) TestRenderGoI18n_InvalidOutputPath() {
@@ -233,7 +429,13 @@ func TestTemplateFunctions(t *testing.T) {
 			name:     "commentSafe multi line",
 			template: `{{.comment | commentSafe}}`,
 			data:     map[string]string{"comment": "Line 1\nLine 2\nLine 3"},
-			expected: "Line 1\n//         Line 2\n//         Line 3",
+			expected: "Line 1\n// Line 2\n// Line 3",
+		},
+		{
+			name:     "commentSafe multi line with prefix",
+			template: `{{commentSafe .comment "\t// "}}`,
+			data:     map[string]string{"comment": "Line 1\n\nLine 3"},
+			expected: "Line 1\n\t//\n\t// Line 3",
 		},
 	}
 
@@ -1,7 +1,11 @@
 // Package utils provides utility functions for string manipulation and formatting.
 package utils
 
-import "strings"
+import (
+	"fmt"
+	"go/token"
+	"strings"
+)
 
 // ToCamelCase converts snake_case to CamelCase (e.g. user_name -> UserName)
 func ToCamelCase(s string) string {
@@ -30,3 +34,25 @@ func SafeGoIdentifier(name string) string {
 	}
 	return name
 }
+
+// ValidatePackageName checks that name can be used as a Go package clause
+func ValidatePackageName(name string) error {
+	if name == "" {
+		return fmt.Errorf("package name cannot be empty")
+	}
+	if goReservedWords[name] {
+		return fmt.Errorf("invalid package name %q: %q is a Go reserved word", name, name)
+	}
+	if name == "_" {
+		return fmt.Errorf("invalid package name %q: the blank identifier cannot be used as a package name", name)
+	}
+	if name == "main" {
+		return fmt.Errorf("invalid package name %q: package main is a program and cannot be imported", name)
+	}
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf(
+			"invalid package name %q: must be a valid Go identifier "+
+				"(letters, digits and underscores, not starting with a digit)", name)
+	}
+	return nil
+}
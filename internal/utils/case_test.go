@@ -105,6 +105,38 @@ func (s *CaseTestSuite) TestToCamelCaseEdgeCases() {
 	}
 }
 
+func (s *CaseTestSuite) TestValidatePackageName() {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"simple name", "i18n", ""},
+		{"underscore", "my_pkg", ""},
+		{"unicode letters", "メッセージ", ""},
+		{"empty", "", "cannot be empty"},
+		{"hyphenated", "my-pkg", "must be a valid Go identifier"},
+		{"numeric leading", "123pkg", "must be a valid Go identifier"},
+		{"dotted", "my.pkg", "must be a valid Go identifier"},
+		{"keyword", "type", "reserved word"},
+		{"keyword func", "func", "reserved word"},
+		{"blank identifier", "_", "blank identifier"},
+		{"main", "main", "cannot be imported"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := ValidatePackageName(tt.input)
+			if tt.wantErr == "" {
+				s.NoError(err)
+				return
+			}
+			s.Require().Error(err)
+			s.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestCaseSuite(t *testing.T) {
 	suite.Run(t, new(CaseTestSuite))
 }
@@ -0,0 +1,68 @@
+package utils
+
+// LocaleName is the display information of a locale for language pickers
+type LocaleName struct {
+	Name       string // English name, e.g. "Japanese"
+	NativeName string // Name in the language itself, e.g. "日本語"
+	RTL        bool   // The script is written right to left
+}
+
+// localeNames is the built-in table of common locales, keyed by BCP 47 tag
+var localeNames = map[string]LocaleName{
+	"ar":      {Name: "Arabic", NativeName: "العربية", RTL: true},
+	"bg":      {Name: "Bulgarian", NativeName: "Български"},
+	"bn":      {Name: "Bengali", NativeName: "বাংলা"},
+	"ca":      {Name: "Catalan", NativeName: "Català"},
+	"cs":      {Name: "Czech", NativeName: "Čeština"},
+	"da":      {Name: "Danish", NativeName: "Dansk"},
+	"de":      {Name: "German", NativeName: "Deutsch"},
+	"el":      {Name: "Greek", NativeName: "Ελληνικά"},
+	"en":      {Name: "English", NativeName: "English"},
+	"en-GB":   {Name: "English (United Kingdom)", NativeName: "English (United Kingdom)"},
+	"en-US":   {Name: "English (United States)", NativeName: "English (United States)"},
+	"es":      {Name: "Spanish", NativeName: "Español"},
+	"es-MX":   {Name: "Spanish (Mexico)", NativeName: "Español (México)"},
+	"fa":      {Name: "Persian", NativeName: "فارسی", RTL: true},
+	"fi":      {Name: "Finnish", NativeName: "Suomi"},
+	"fil":     {Name: "Filipino", NativeName: "Filipino"},
+	"fr":      {Name: "French", NativeName: "Français"},
+	"fr-CA":   {Name: "French (Canada)", NativeName: "Français (Canada)"},
+	"he":      {Name: "Hebrew", NativeName: "עברית", RTL: true},
+	"hi":      {Name: "Hindi", NativeName: "हिन्दी"},
+	"hr":      {Name: "Croatian", NativeName: "Hrvatski"},
+	"hu":      {Name: "Hungarian", NativeName: "Magyar"},
+	"id":      {Name: "Indonesian", NativeName: "Bahasa Indonesia"},
+	"it":      {Name: "Italian", NativeName: "Italiano"},
+	"ja":      {Name: "Japanese", NativeName: "日本語"},
+	"ko":      {Name: "Korean", NativeName: "한국어"},
+	"ms":      {Name: "Malay", NativeName: "Bahasa Melayu"},
+	"nb":      {Name: "Norwegian Bokmål", NativeName: "Norsk bokmål"},
+	"nl":      {Name: "Dutch", NativeName: "Nederlands"},
+	"pl":      {Name: "Polish", NativeName: "Polski"},
+	"pt":      {Name: "Portuguese", NativeName: "Português"},
+	"pt-BR":   {Name: "Portuguese (Brazil)", NativeName: "Português (Brasil)"},
+	"pt-PT":   {Name: "Portuguese (Portugal)", NativeName: "Português (Portugal)"},
+	"ro":      {Name: "Romanian", NativeName: "Română"},
+	"ru":      {Name: "Russian", NativeName: "Русский"},
+	"sk":      {Name: "Slovak", NativeName: "Slovenčina"},
+	"sr":      {Name: "Serbian", NativeName: "Српски"},
+	"sv":      {Name: "Swedish", NativeName: "Svenska"},
+	"sw":      {Name: "Swahili", NativeName: "Kiswahili"},
+	"ta":      {Name: "Tamil", NativeName: "தமிழ்"},
+	"th":      {Name: "Thai", NativeName: "ไทย"},
+	"tr":      {Name: "Turkish", NativeName: "Türkçe"},
+	"uk":      {Name: "Ukrainian", NativeName: "Українська"},
+	"ur":      {Name: "Urdu", NativeName: "اردو", RTL: true},
+	"vi":      {Name: "Vietnamese", NativeName: "Tiếng Việt"},
+	"zh":      {Name: "Chinese", NativeName: "中文"},
+	"zh-CN":   {Name: "Chinese (China)", NativeName: "中文（中国）"},
+	"zh-Hans": {Name: "Chinese (Simplified)", NativeName: "简体中文"},
+	"zh-Hant": {Name: "Chinese (Traditional)", NativeName: "繁體中文"},
+	"zh-TW":   {Name: "Chinese (Taiwan)", NativeName: "中文（台灣）"},
+}
+
+// LookupLocaleName returns the display information of locale from the built-in table
+func LookupLocaleName(locale string) (LocaleName, bool) {
+	name, ok := localeNames[locale]
+	return name, ok
+}
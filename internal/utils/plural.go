@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// PluralCategories lists the CLDR plural categories in canonical order
+var PluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// PluralCategoryIndex returns the canonical position of a CLDR plural category, or -1 if unknown
+func PluralCategoryIndex(category string) int {
+	for i, c := range PluralCategories {
+		if c == category {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsPluralCategory reports whether the given key is a valid CLDR plural category
+func IsPluralCategory(category string) bool {
+	return PluralCategoryIndex(category) != -1
+}
+
+// HasPluralForms reports whether the CLDR rules of locale distinguish plural forms. Locales such as
+// ja and zh use the other form for every count, so a plain string is their complete translation.
+func HasPluralForms(locale string) bool {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return true
+	}
+	for i := 0; i <= 200; i++ {
+		if plural.Cardinal.MatchPlural(tag, i, 0, 0, 0, 0) != plural.Other {
+			return true
+		}
+	}
+	// Decimals such as 1.5 select a form of their own in some locales
+	return plural.Cardinal.MatchPlural(tag, 1, 1, 1, 5, 5) != plural.Other
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PluralTestSuite struct {
+	suite.Suite
+}
+
+func (s *PluralTestSuite) TestHasPluralForms() {
+	tests := []struct {
+		locale   string
+		expected bool
+	}{
+		{"en", true},
+		{"fr", true},
+		{"ru", true},
+		{"pt-BR", true},
+		{"ja", false},
+		{"zh", false},
+		{"ko", false},
+		{"not a locale", true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.locale, func() {
+			s.Equal(tt.expected, HasPluralForms(tt.locale))
+		})
+	}
+}
+
+func TestPluralSuite(t *testing.T) {
+	suite.Run(t, new(PluralTestSuite))
+}
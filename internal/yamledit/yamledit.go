@@ -0,0 +1,97 @@
+// Package yamledit rewrites YAML files through yaml.v3 nodes so comments, key order and quoting survive edits.
+package yamledit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Indent is the indentation used when encoding YAML
+const Indent = 2
+
+// File is a YAML file loaded for in-place editing
+type File struct {
+	Path string
+	Doc  yaml.Node
+
+	original []byte
+	mode     os.FileMode
+}
+
+// Read parses a YAML file into a node tree that can be edited and written back
+func Read(path string) (*File, error) {
+	content, err := os.ReadFile(path) // #nosec G304 - Editing user-specified files is intentional
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %q: %w", path, err)
+	}
+
+	file := &File{Path: path, original: content, mode: info.Mode().Perm()}
+	if err := yaml.Unmarshal(content, &file.Doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+	return file, nil
+}
+
+// Root returns the top-level node of the document, or nil for an empty document
+func (f *File) Root() *yaml.Node {
+	if f.Doc.Kind != yaml.DocumentNode || len(f.Doc.Content) == 0 {
+		return nil
+	}
+	return f.Doc.Content[0]
+}
+
+// Bytes encodes the edited document.
+//
+// When the edits leave the node tree unchanged, the original content is returned as is,
+// so layout details the encoder would normalize (indentation, comment alignment) are kept.
+func (f *File) Bytes() ([]byte, error) {
+	encoded, err := Encode(&f.Doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %q: %w", f.Path, err)
+	}
+
+	var pristine yaml.Node
+	if err := yaml.Unmarshal(f.original, &pristine); err == nil {
+		if baseline, err := Encode(&pristine); err == nil && bytes.Equal(baseline, encoded) {
+			return f.original, nil
+		}
+	}
+	return encoded, nil
+}
+
+// Write stores the edited document with the original file mode and reports whether the content changed
+func (f *File) Write() (bool, error) {
+	content, err := f.Bytes()
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(content, f.original) {
+		return false, nil
+	}
+	if err := os.WriteFile(f.Path, content, f.mode); err != nil {
+		return false, fmt.Errorf("failed to write %q: %w", f.Path, err)
+	}
+	f.original = content
+	return true, nil
+}
+
+// Encode encodes a node tree with the shared indentation
+func Encode(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(Indent)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
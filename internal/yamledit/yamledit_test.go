@@ -0,0 +1,100 @@
+package yamledit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// commented uses block and inline comments, mixed quoting and an indentation the encoder would normalize
+const commented = `# Messages shown on the home page
+
+# Greeting for signed-in users
+Hello:
+    ja: 'こんにちは {{.name}}'   # informal
+    en: Hello {{.name}}
+UserCount:
+    ja: "{{.Count}}人のユーザー"
+    en:
+        one: "{{.Count}} user"   # singular
+        other: "{{.Count}} users"
+# trailing comment
+`
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "messages.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0640))
+	return path
+}
+
+func TestWriteUnchanged(t *testing.T) {
+	path := writeFile(t, commented)
+
+	file, err := Read(path)
+	require.NoError(t, err)
+
+	changed, err := file.Write()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, commented, string(content), "a no-op rewrite must keep the file byte-for-byte")
+}
+
+func TestWriteEdited(t *testing.T) {
+	path := writeFile(t, commented)
+
+	file, err := Read(path)
+	require.NoError(t, err)
+	hello := file.Root().Content[1]
+	hello.Content[3].Value = "Hi {{.name}}"
+
+	changed, err := file.Write()
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# Messages shown on the home page
+
+# Greeting for signed-in users
+Hello:
+  ja: 'こんにちは {{.name}}' # informal
+  en: Hi {{.name}}
+UserCount:
+  ja: "{{.Count}}人のユーザー"
+  en:
+    one: "{{.Count}} user" # singular
+    other: "{{.Count}} users"
+# trailing comment
+`, string(content), "comments, key order and quoting of untouched entries are preserved")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+}
+
+func TestRoot(t *testing.T) {
+	file, err := Read(writeFile(t, ""))
+	require.NoError(t, err)
+	assert.Nil(t, file.Root())
+
+	file, err = Read(writeFile(t, "key: value\n"))
+	require.NoError(t, err)
+	require.NotNil(t, file.Root())
+	assert.Equal(t, yaml.MappingNode, file.Root().Kind)
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := Read(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = Read(writeFile(t, "key: [unclosed\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+// Package i18ngen exposes the code generator to Go programs, such as codegen tools embedding
+// i18ngen, that need the generated source instead of running the CLI.
+package i18ngen
+
+import (
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+)
+
+// Config holds the generation settings; its fields mirror the keys of the configuration file
+type Config = config.Config
+
+// RuntimePlaceholder declares a placeholder resolved by a function set at runtime
+type RuntimePlaceholder = config.RuntimePlaceholder
+
+// LocaleName overrides the built-in display information of a locale in the generated LocaleInfo
+type LocaleName = config.LocaleName
+
+var (
+	// ErrInvalidConfig classifies errors caused by the configuration
+	ErrInvalidConfig = generator.ErrInvalidConfig
+	// ErrInvalidInput classifies errors caused by message or placeholder files failing validation
+	ErrInvalidInput = generator.ErrInvalidInput
+)
+
+// LoadConfig reads a YAML, JSON or TOML configuration file, chosen by the file extension.
+// Relative paths in it are resolved against the directory of the file; a missing file yields
+// an empty configuration.
+func LoadConfig(path string) (*Config, error) {
+	return config.LoadConfig(path)
+}
+
+// Generate returns the formatted Go source generated for cfg without writing any file.
+// Errors can be classified with errors.Is against ErrInvalidConfig and ErrInvalidInput.
+func Generate(cfg *Config) ([]byte, error) {
+	return generator.Generate(cfg)
+}
+
+// Run generates the code for cfg and writes it, together with the optional usage example,
+// smoke test and trace, to cfg.OutputDir
+func Run(cfg *Config) error {
+	return generator.Run(cfg)
+}
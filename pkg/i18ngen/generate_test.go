@@ -0,0 +1,39 @@
+package i18ngen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "messages.yaml"), []byte(`Hello:
+  ja: "こんにちは {{.name}}"
+  en: "Hello {{.name}}"
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "i18ngen.yaml"), []byte(`locales: [en, ja]
+messages: messages.yaml
+placeholders: placeholders/*.yaml
+output_package: translations
+`), 0644))
+
+	cfg, err := LoadConfig(filepath.Join(tempDir, "i18ngen.yaml"))
+	require.NoError(t, err)
+	code, err := Generate(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "package translations")
+	assert.Contains(t, string(code), "func NewHello(name NameValue) Hello")
+	assert.NoFileExists(t, filepath.Join(tempDir, "i18n.gen.go"), "Generate writes no file")
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		invalid := *cfg
+		invalid.OutputPackage = "not-a-package"
+		_, err := Generate(&invalid)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
@@ -0,0 +1,22 @@
+package i18ngen
+
+import "github.com/hacomono-lib/go-i18ngen/internal/parser"
+
+// Field is a placeholder referenced by a message template
+type Field struct {
+	Name   string // Base name, e.g. "entity" for {{.entity:from}}
+	Suffix string // Suffix distinguishing placeholders of the same name, e.g. "from"; empty if none
+}
+
+// ParseMessageTemplate lists the fields of a message template in the order the generator sees them,
+// for tools such as editor plugins that complete placeholders. Suffix notation is split at the default
+// separator, so {{.entity:from}} is the field entity with suffix from, and fields passed to template
+// functions, as in {{money .amount}}, or tested by control actions, as in {{if .reason}}, are included.
+func ParseMessageTemplate(s string) []Field {
+	infos := parser.ExtractFieldInfos(s, "")
+	fields := make([]Field, 0, len(infos))
+	for _, info := range infos {
+		fields = append(fields, Field{Name: info.Name, Suffix: info.Suffix})
+	}
+	return fields
+}
@@ -0,0 +1,20 @@
+package i18ngen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessageTemplate(t *testing.T) {
+	assert.Equal(t, []Field{
+		{Name: "entity", Suffix: "from"},
+		{Name: "amount"},
+		{Name: "entity", Suffix: "to"},
+		{Name: "reason"},
+	}, ParseMessageTemplate("{{.entity:from}} paid {{money .amount}} to {{.entity:to | title}}{{if .reason}}.{{end}}"))
+
+	assert.Equal(t, []Field{{Name: "items"}, {Name: "total"}}, ParseMessageTemplate("{{range .items}}{{.name}}{{end}} of {{$.total}}"),
+		"selectors inside range refer to the element, except $-rooted ones")
+	assert.Empty(t, ParseMessageTemplate("no placeholders"))
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutofillFrom(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  en: "{{.entity}} not found"
+ItemsLeft:
+  ja: "残り{{.Count}}個"
+  en:
+    one: "{{.Count}} item left"
+    other: "{{.Count}} items left"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+group:
+  en: "Group"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.AutofillFrom = "en"
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), `//   - [ja] "{{.entity}} not found" (untranslated, copied from en)`)
+	assert.Contains(t, string(code), `//   • [ja] "Group" (untranslated, copied from en)`)
+	assert.NotContains(t, string(code), `"残り{{.Count}}個" (untranslated`, "existing translations are not flagged")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestAutofillFrom(t *testing.T) {
+	if got := NewEntityNotFound(EntityTexts.Group).Localize("ja"); got != "Group not found" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewEntityNotFound(EntityTexts.User).Localize("ja"); got != "ユーザー not found" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewItemsLeft().WithPluralCount(3).Localize("ja"); got != "残り3個" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestConditionalSections(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `UserNotFound:
+  ja: "ユーザーが見つかりません{{if .reason}}（{{.reason}}）{{end}}"
+  en: "User not found{{if .reason}}: {{.reason}}{{end}}"
+Notice:
+  ja: "{{with .detail}}詳細: {{.}}{{else}}詳細なし{{end}}"
+  en: "{{with .detail}}Details: {{.}}{{else}}No details{{end}}"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestConditionalSections(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{NewUserNotFound(NewReasonValue("deleted")).Localize("en"), "User not found: deleted"},
+		{NewUserNotFound(NewReasonValue("")).Localize("en"), "User not found"},
+		{NewUserNotFound(NewReasonValue("削除済み")).Localize("ja"), "ユーザーが見つかりません（削除済み）"},
+		{NewUserNotFound(NewReasonValue("")).Localize("ja"), "ユーザーが見つかりません"},
+		// A field only tested by a control action is still a constructor argument
+		{NewNotice(NewDetailValue("late")).Localize("en"), "Details: late"},
+		{NewNotice(NewDetailValue("")).Localize("en"), "No details"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+`)
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestCountType(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `ItemCount:
+  ja: "{{.item}}が{{.Count}}個"
+  en:
+    one: "{{.Count}} {{.item}}"
+    other: "{{.Count}} {{.item}}"
+`,
+		"placeholders/item.yaml": `book:
+  ja: 本
+  en:
+    one: book
+    other: books
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.CountType = config.CountTypeInt64
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestCountType(t *testing.T) {
+	var count int64 = 3
+	if got, want := NewItemCount(ItemTexts.Book).WithPluralCount(count).Localize("en"), "3 books"; got != want {
+		t.Errorf("Localize(en) = %q, want %q", got, want)
+	}
+	if got, want := NewItemCount(ItemTexts.Book).WithPluralCount(1).Localize("en"), "1 book"; got != want {
+		t.Errorf("Localize(en) = %q, want %q", got, want)
+	}
+}
+`)
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestMessageCoverage(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Hello:
+  ja: "こんにちは"
+  en: "Hello"
+Bye:
+  en: "Bye"
+Thanks:
+  ja: "ありがとう"
+  fr: "Merci"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.Coverage = true
+		cfg.AutofillFrom = "en"
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMessageCoverage(t *testing.T) {
+	if want := []string{"Bye", "Hello", "Thanks"}; !reflect.DeepEqual(AllMessageIDs, want) {
+		t.Errorf("AllMessageIDs = %v, want %v", AllMessageIDs, want)
+	}
+
+	total, byLocale := MessageCoverage()
+	if total != 3 {
+		t.Errorf("total = %d, want 3", total)
+	}
+	// Bye is autofilled into ja, which does not count as a translation, and fr is not configured
+	if want := map[string]int{"ja": 2, "en": 2}; !reflect.DeepEqual(byLocale, want) {
+		t.Errorf("byLocale = %v, want %v", byLocale, want)
+	}
+}
+`)
+}
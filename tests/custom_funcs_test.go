@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestCustomTemplateFunctions(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `OrderTotal:
+  ja: "合計: {{money .amount}}"
+  en: "Total: {{money .amount}}{{locale \"en\" \" (incl. tax)\"}}"
+Greeting:
+  ja: "こんにちは"
+  en: "Hello"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.TemplateFunctions = []string{"money", "shorten"}
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCustomTemplateFunctions(t *testing.T) {
+	func() {
+		defer func() {
+			r := recover()
+			if r == nil || !strings.Contains(fmt.Sprint(r), "not set in CustomFuncs") {
+				t.Errorf("expected the stub to fail rendering, got %v", r)
+			}
+		}()
+		NewOrderTotal(NewAmountValue("100")).Localize("en")
+	}()
+
+	CustomFuncs["money"] = func(amount string) string { return "$" + amount }
+	if got := NewOrderTotal(NewAmountValue("100")).Localize("en"); got != "Total: $100 (incl. tax)" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewOrderTotal(NewAmountValue("100")).Localize("ja"); got != "合計: $100" {
+		t.Errorf("got %q", got)
+	}
+	if _, ok := TemplateFuncs("en")["shorten"]; !ok {
+		t.Error("TemplateFuncs should include the custom functions")
+	}
+	if !funcMessages["OrderTotal"] || funcMessages["Greeting"] {
+		t.Errorf("unexpected function messages: %v", funcMessages)
+	}
+}
+`)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedData(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+ItemCount:
+  ja:
+    other: "{{.Count}}件"
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User \"admin\""
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.DataLayout = config.DataLayoutEmbedFile
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, generator.OutputFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "//go:embed i18n.gen.messages.en.yaml i18n.gen.messages.ja.yaml i18n.gen.placeholders.yaml")
+	assert.NotContains(t, string(code), "[]byte(`")
+	for _, name := range []string{
+		templatex.MessageDataFileName("en"),
+		templatex.MessageDataFileName("ja"),
+		templatex.PlaceholderDataFileName,
+	} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestEmbedData(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{NewEntityNotFound(EntityTexts.User).Localize("ja"), "ユーザーが見つかりません"},
+		{NewEntityNotFound(EntityTexts.User).Localize("en"), "User \"admin\" not found"},
+		{NewItemCount().WithPluralCount(1).Localize("en"), "1 item"},
+		{NewItemCount().WithPluralCount(3).Localize("ja"), "3件"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+`)
+
+	removed, err := generator.Clean(dir, false)
+	require.NoError(t, err)
+	assert.Len(t, removed, 4)
+	assert.NoFileExists(t, filepath.Join(dir, templatex.PlaceholderDataFileName))
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestEnumPlaceholders(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `OrderStatus:
+  ja: "注文 {{.order}} は {{.status}} です"
+  en: "Order {{.order}} is {{.status}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.EnumPlaceholders = map[string][]string{"status": {"pending", "in_review", "done"}}
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestEnumPlaceholders(t *testing.T) {
+	msg := NewOrderStatus(NewOrderValue("#42"), StatusInReview)
+	if got := msg.Localize("en"); got != "Order #42 is in_review" {
+		t.Errorf("got %q", got)
+	}
+	if got := msg.Localize("ja"); got != "注文 #42 は in_review です" {
+		t.Errorf("got %q", got)
+	}
+
+	status, err := ParseStatus("done")
+	if err != nil || status != StatusDone {
+		t.Errorf("ParseStatus(done) = %v, %v", status, err)
+	}
+	if _, err := ParseStatus("cancelled"); err == nil {
+		t.Error("expected an error for a value outside the enum")
+	}
+	if StatusPending.ID() != "pending" {
+		t.Errorf("got %q", StatusPending.ID())
+	}
+}
+`)
+}
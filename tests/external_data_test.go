@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalData(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.DataLayout = config.DataLayoutExternal
+		// go test runs in the package directory, where the message files are written
+		cfg.TranslationsDir = "."
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, generator.OutputFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "var messageData = map[string][]byte{}")
+	assert.NotContains(t, string(code), "go:embed")
+	assert.FileExists(t, filepath.Join(dir, templatex.MessageDataFileName("ja")))
+	assert.NoFileExists(t, filepath.Join(dir, templatex.PlaceholderDataFileName))
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExternalData(t *testing.T) {
+	if got := NewEntityNotFound(EntityTexts.User).Localize("ja"); got != "ユーザーが見つかりません" {
+		t.Errorf("Localize(ja) = %q", got)
+	}
+
+	// Edited message files are picked up without regenerating
+	dir := t.TempDir()
+	content := []byte("EntityNotFound: \"No {{.entity}} here\"\n")
+	if err := os.WriteFile(filepath.Join(dir, "i18n.gen.messages.en.yaml"), content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadTranslations(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewEntityNotFound(EntityTexts.User).Localize("en"); got != "No User here" {
+		t.Errorf("Localize(en) = %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestFieldSetters(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません（{{.reason}}）"
+  en: "{{.entity}} not found ({{.reason}})"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+product:
+  ja: "製品"
+  en: "Product"
+`,
+		"placeholders/reason.yaml": `already_deleted:
+  ja: "削除済み"
+  en: "already deleted"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestFieldSetters(t *testing.T) {
+	base := NewEntityNotFound(EntityTexts.User, ReasonTexts.AlreadyDeleted)
+	product := base.WithEntity(EntityTexts.Product)
+
+	if got := product.Localize("en"); got != "Product not found (already deleted)" {
+		t.Errorf("got %q", got)
+	}
+	// Setters return a copy and leave the original message untouched
+	if got := base.Localize("en"); got != "User not found (already deleted)" {
+		t.Errorf("original changed: %q", got)
+	}
+
+	// Messages can be built incrementally from the zero value
+	var msg EntityNotFound
+	msg = msg.WithEntity(EntityTexts.User).WithReason(ReasonTexts.AlreadyDeleted)
+	if got := msg.Localize("ja"); got != "ユーザーが見つかりません（削除済み）" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemBackend(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Greeting:
+  ja: "こんにちは {{.name}}"
+  en: "Hello {{.name}}"
+Farewell:
+  ja: "さようなら"
+  en: "Goodbye"
+`,
+	}
+
+	t.Run("default backend does not generate the loader", func(t *testing.T) {
+		dir := generatePackage(t, files, nil)
+		code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "func LoadTranslations")
+	})
+
+	t.Run("filesystem backend overlays translations at runtime", func(t *testing.T) {
+		dir := generatePackage(t, files, func(cfg *config.Config) {
+			cfg.Backend = config.BackendFilesystem
+			cfg.TranslationsDir = "testdata/missing"
+		})
+
+		code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "func LoadTranslations(dir string) error")
+		assert.Contains(t, string(code), `var TranslationsDir = "testdata/missing"`)
+
+		runPackageTest(t, dir, `package generated
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadTranslations(t *testing.T) {
+	msg := NewGreeting(NewNameValue("Ann"))
+	if got := msg.Localize("en"); got != "Hello Ann" {
+		t.Fatalf("embedded: got %q", got)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "active.en.yaml"), []byte("Greeting: \"Hi {{.name}}\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadTranslations(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := msg.Localize("en"); got != "Hi Ann" {
+		t.Fatalf("filesystem: got %q", got)
+	}
+	// Messages missing from the files fall back to embedded data
+	if got := NewFarewell().Localize("en"); got != "Goodbye" {
+		t.Fatalf("fallback: got %q", got)
+	}
+
+	stop := WatchTranslations(dir, 10*time.Millisecond, func(err error) { t.Error(err) })
+	defer stop()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(dir, "active.en.yaml"), []byte("Greeting: \"Hey {{.name}}\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for msg.Localize("en") != "Hey Ann" {
+		if time.Now().After(deadline) {
+			t.Fatalf("watch: got %q", msg.Localize("en"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+`)
+	})
+}
+
+func TestMessageBackend(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Greeting:
+  backend: filesystem
+  ja: "こんにちは {{.name}}"
+  en: "Hello {{.name}}"
+Farewell:
+  ja: "さようなら"
+  en: "Goodbye"
+`,
+	}
+
+	// The default go-i18n backend keeps Farewell embedded while Greeting is loaded at runtime
+	dir := generatePackage(t, files, nil)
+	runPackageTest(t, dir, `package generated
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMessageBackend(t *testing.T) {
+	dir := t.TempDir()
+	data := "Greeting: \"Hi {{.name}}\"\nFarewell: \"Bye\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "active.en.yaml"), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadTranslations(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewGreeting(NewNameValue("Ann")).Localize("en"); got != "Hi Ann" {
+		t.Errorf("filesystem message: got %q", got)
+	}
+	if got := NewFarewell().Localize("en"); got != "Goodbye" {
+		t.Errorf("embedded message: got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+
+	"github.com/stretchr/testify/require"
+)
+
+// generatePackage writes the given input files (relative path -> content) to a temporary
+// directory, generates code from them and returns the directory of the generated package.
+// The package lives inside the module so it can be compiled against the module's dependencies.
+func generatePackage(t *testing.T, files map[string]string, configure func(cfg *config.Config)) string {
+	t.Helper()
+
+	inputDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(inputDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	outputDir, err := os.MkdirTemp(".", "generated_")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(outputDir) })
+
+	cfg := &config.Config{
+		Locales:          []string{"ja", "en"},
+		Compound:         true,
+		MessagesGlob:     filepath.Join(inputDir, "messages", "*.yaml"),
+		PlaceholdersGlob: filepath.Join(inputDir, "placeholders", "*.yaml"),
+		OutputDir:        outputDir,
+		OutputPackage:    "generated",
+	}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	require.NoError(t, generator.Run(cfg))
+	return outputDir
+}
+
+// runPackageTest adds testSource as a test file to the generated package and runs `go test` on it
+func runPackageTest(t *testing.T, packageDir, testSource string) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping compilation of generated code in short mode")
+	}
+
+	testFile := filepath.Join(packageDir, "generated_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(testSource), 0644))
+
+	cmd := exec.Command("go", "test", "./"+filepath.ToSlash(packageDir)) // #nosec G204 - test helper
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "generated package tests failed:\n%s", output)
+}
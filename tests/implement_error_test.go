@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestImplementError(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+SaveFailed:
+  ja: "保存に失敗しました: {{.error}}"
+  en: "Save failed: {{.error}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.ImplementError = true
+		cfg.PrimaryLocale = "en"
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func find() error {
+	return NewEntityNotFound(EntityTexts.User)
+}
+
+func TestImplementError(t *testing.T) {
+	err := find()
+	if got := err.Error(); got != "User not found" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	// Wrapped messages are still found and can be localized for the request
+	wrapped := fmt.Errorf("lookup: %w", err)
+	var notFound EntityNotFound
+	if !errors.As(wrapped, &notFound) {
+		t.Fatal("errors.As did not find EntityNotFound")
+	}
+	if got := notFound.Localize("ja"); got != "ユーザーが見つかりません" {
+		t.Errorf("Localize(ja) = %q", got)
+	}
+	if got := wrapped.Error(); got != "lookup: User not found" {
+		t.Errorf("wrapped = %q", got)
+	}
+
+	// The cause is reachable through the message but not part of its text
+	errNoRows := errors.New("no rows in result set")
+	err = fmt.Errorf("repo: %w", NewEntityNotFound(EntityTexts.User).WithCause(errNoRows))
+	if !errors.Is(err, errNoRows) {
+		t.Error("errors.Is did not find the cause")
+	}
+	if !errors.As(err, &notFound) || notFound.Localize("ja") != "ユーザーが見つかりません" {
+		t.Errorf("errors.As found %q", notFound.Localize("ja"))
+	}
+	if got := err.Error(); got != "repo: User not found" {
+		t.Errorf("Error() with cause = %q", got)
+	}
+	if NewEntityNotFound(EntityTexts.User).Unwrap() != nil {
+		t.Error("Unwrap without a cause is not nil")
+	}
+
+	// A field named error does not collide with the Error method
+	failed := NewSaveFailed(NewErrorValue("disk full"))
+	if got := failed.Error(); got != "Save failed: disk full" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+`)
+}
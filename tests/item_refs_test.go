@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestPlaceholderItemReferences(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `UserNotFound:
+  ja: "{{entity \"user\"}}が見つかりません: {{.id}}"
+  en: "{{ entity \"user\" }} not found: {{.id}}"
+ItemsLeft:
+  ja: "残り{{.Count}}{{unit \"item\"}}"
+  en:
+    one: "{{.Count}} {{unit \"item\"}} left"
+    other: "{{.Count}} {{unit \"item\"}} left"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+		"placeholders/unit.yaml": `item:
+  ja: "個"
+  en:
+    one: "item"
+    other: "items"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestPlaceholderItemReferences(t *testing.T) {
+	// The referenced item is fixed, so only {{.id}} is a constructor argument
+	if got := NewUserNotFound(NewIdValue("42")).Localize("en"); got != "User not found: 42" {
+		t.Errorf("en: got %q", got)
+	}
+	if got := NewUserNotFound(NewIdValue("42")).Localize("ja"); got != "ユーザーが見つかりません: 42" {
+		t.Errorf("ja: got %q", got)
+	}
+
+	// Plural items follow the message count
+	if got := NewItemsLeft().WithPluralCount(1).Localize("en"); got != "1 item left" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewItemsLeft().WithPluralCount(3).Localize("en"); got != "3 items left" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
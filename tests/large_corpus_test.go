@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	largeCorpusFiles        = 20
+	largeCorpusFileMessages = 50
+	// largeCorpusHeapPerMessage bounds the heap in use while generating, per message of the corpus.
+	// Generation peaks at about 55 KiB per message, so the budget catches the peak doubling.
+	largeCorpusHeapPerMessage = 128 << 10
+)
+
+// writeLargeCorpus writes a synthetic corpus of largeCorpusFiles message files and returns its config
+func writeLargeCorpus(tb testing.TB) *config.Config {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	require.NoError(tb, os.MkdirAll(filepath.Join(dir, "messages"), 0755))
+	require.NoError(tb, os.MkdirAll(filepath.Join(dir, "placeholders"), 0755))
+	require.NoError(tb, os.WriteFile(filepath.Join(dir, "placeholders", "entity.yaml"),
+		[]byte("user:\n  ja: ユーザー\n  en: User\n"), 0644))
+
+	for f := 0; f < largeCorpusFiles; f++ {
+		var b strings.Builder
+		for m := 0; m < largeCorpusFileMessages; m++ {
+			fmt.Fprintf(&b, "Area%03dMessage%03d:\n", f, m)
+			fmt.Fprintf(&b, "  ja: \"{{.entity}}の{{.name}}を更新しました (%d)\"\n", m)
+			fmt.Fprintf(&b, "  en: \"Updated {{.name}} of {{.entity}} (%d)\"\n", m)
+		}
+		path := filepath.Join(dir, "messages", fmt.Sprintf("area%03d.yaml", f))
+		require.NoError(tb, os.WriteFile(path, []byte(b.String()), 0644))
+	}
+
+	return &config.Config{
+		Locales:          []string{"ja", "en"},
+		Compound:         true,
+		MessagesGlob:     filepath.Join(dir, "messages", "*.yaml"),
+		PlaceholdersGlob: filepath.Join(dir, "placeholders", "*.yaml"),
+		OutputDir:        dir,
+		OutputPackage:    "generated",
+	}
+}
+
+// peakHeapInUse runs fn while sampling the heap in use and returns the highest sample
+func peakHeapInUse(fn func()) uint64 {
+	runtime.GC()
+	var peak uint64
+	var mu sync.Mutex
+	sample := func() {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		mu.Lock()
+		peak = max(peak, stats.HeapInuse)
+		mu.Unlock()
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				sample()
+			}
+		}
+	}()
+	fn()
+	close(done)
+	<-stopped
+	sample()
+	return peak
+}
+
+func TestLargeCorpus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large corpus generation in short mode")
+	}
+	cfg := writeLargeCorpus(t)
+	budget := uint64(largeCorpusFiles*largeCorpusFileMessages) * largeCorpusHeapPerMessage
+
+	var outputs [][]byte
+	for _, maxParallel := range []int{1, 8} {
+		cfg.MaxParallel = maxParallel
+		var code []byte
+		var err error
+		peak := peakHeapInUse(func() { code, err = generator.Generate(cfg) })
+		require.NoError(t, err, "max parallel %d", maxParallel)
+		assert.Less(t, peak, budget, "max parallel %d exceeded the heap budget", maxParallel)
+		t.Logf("max parallel %d: %d bytes generated, peak heap in use %d MiB", maxParallel, len(code), peak>>20)
+		outputs = append(outputs, code)
+	}
+
+	assert.Contains(t, string(outputs[0]), "func NewArea019Message049(")
+	assert.Equal(t, outputs[0], outputs[1], "output must not depend on max parallel")
+}
+
+func BenchmarkGenerateLargeCorpus(b *testing.B) {
+	cfg := writeLargeCorpus(b)
+	for _, maxParallel := range []int{1, 0} {
+		b.Run(fmt.Sprintf("max_parallel=%d", maxParallel), func(b *testing.B) {
+			cfg.MaxParallel = maxParallel
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := generator.Generate(cfg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
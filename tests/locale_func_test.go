@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestLocaleFunc(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `FilesDeleted:
+  en: '{{.actor}} deleted the file{{locale "en" "s" ""}}'
+Greeting:
+  ja: "こんにちは"
+  en: "Hello"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	// The English text is shared with ja through autofill, with a per-locale tweak
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.AutofillFrom = "en"
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestLocaleFunc(t *testing.T) {
+	msg := NewFilesDeleted(NewActorValue("Alice"))
+	cases := map[string]string{
+		"en":    "Alice deleted the files",
+		"en-US": "Alice deleted the files",
+		"ja":    "Alice deleted the file",
+	}
+	for locale, want := range cases {
+		if got := msg.Localize(locale); got != want {
+			t.Errorf("%s: got %q, want %q", locale, got, want)
+		}
+	}
+	if got := NewGreeting().Localize("en"); got != "Hello" {
+		t.Errorf("got %q", got)
+	}
+	if !funcMessages["FilesDeleted"] || funcMessages["Greeting"] {
+		t.Errorf("unexpected function messages: %v", funcMessages)
+	}
+}
+`)
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Greeting:
+  ja: "こんにちは"
+  en: "Hello"
+`,
+	}
+
+	// TemplateFuncs is generated even when no message uses the locale function
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("footer").Funcs(TemplateFuncs("en-GB")).Parse(
+		"{{locale \"en\" \"Regards\" \"Best\"}}"))
+	var out strings.Builder
+	if err := tmpl.Execute(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "Regards" {
+		t.Errorf("got %q", out.String())
+	}
+	if got := TemplateFuncs("ja")["locale"].(func(string, string, ...string) string)("en", "Regards", "Best"); got != "Best" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
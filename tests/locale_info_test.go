@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestLocaleInfo(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Greeting:
+  ja: "こんにちは"
+  en: "Hello"
+  ar: "مرحبا"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.Locales = []string{"ja", "en", "ar"}
+		cfg.LocaleInfo = true
+		cfg.LocaleNames = map[string]config.LocaleName{
+			"en": {Name: "English", NativeName: "English (custom)"},
+		}
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestLocaleInfo(t *testing.T) {
+	if got := LocaleInfo["ja"]; got.Name != "Japanese" || got.NativeName != "日本語" || got.RTL {
+		t.Errorf("LocaleInfo[ja] = %+v", got)
+	}
+	if got := LocaleInfo["ar"]; got.NativeName != "العربية" || !got.RTL {
+		t.Errorf("LocaleInfo[ar] = %+v", got)
+	}
+	if got := LocaleInfo["en"].NativeName; got != "English (custom)" {
+		t.Errorf("LocaleInfo[en].NativeName = %q, want the locale_info override", got)
+	}
+	if len(LocaleInfo) != 3 {
+		t.Errorf("len(LocaleInfo) = %d, want 3", len(LocaleInfo))
+	}
+}
+`)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestLocalizeByID(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}} {{.name}} が見つかりません"
+  en: "{{.entity}} {{.name}} not found"
+OrderStatus:
+  ja: "注文は {{.status}} です"
+  en: "Order is {{.status}}"
+Hello:
+  ja: "こんにちは"
+  en: "Hello"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.LocalizeByID = true
+		cfg.EnumPlaceholders = map[string][]string{"status": {"pending", "done"}}
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLocalizeByID(t *testing.T) {
+	got, err := LocalizeByID("EntityNotFound", "en", map[string]interface{}{
+		"entity": EntityTexts.User,
+		"name":   "Ann",
+	})
+	if err != nil || got != "User Ann not found" {
+		t.Errorf("LocalizeByID = %q, %v", got, err)
+	}
+
+	got, err = LocalizeByID("OrderStatus", "en", map[string]interface{}{"status": "done"})
+	if err != nil || got != "Order is done" {
+		t.Errorf("LocalizeByID = %q, %v", got, err)
+	}
+
+	got, err = LocalizeByID("Hello", "ja", nil)
+	if err != nil || got != "こんにちは" {
+		t.Errorf("LocalizeByID = %q, %v", got, err)
+	}
+
+	msg, err := NewLocalizableByID("EntityNotFound", map[string]interface{}{
+		"entity": EntityTexts.User,
+		"name":   NewNameValue("Ann"),
+	})
+	if err != nil || msg != NewEntityNotFound(EntityTexts.User, NewNameValue("Ann")) {
+		t.Errorf("NewLocalizableByID = %v, %v", msg, err)
+	}
+}
+
+func TestLocalizeByIDErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		messageID string
+		params    map[string]interface{}
+		want      string
+	}{
+		{"unknown message", "Missing", nil, "unknown message \"Missing\""},
+		{"missing and unexpected keys", "EntityNotFound", map[string]interface{}{"name": "Ann", "user": "x", "age": 3},
+			"message \"EntityNotFound\": missing params: entity; unexpected params: age, user"},
+		{"wrong type", "EntityNotFound", map[string]interface{}{"entity": "user", "name": "Ann"},
+			"param \"entity\" must be EntityText, got string"},
+		{"invalid enum value", "OrderStatus", map[string]interface{}{"status": "lost"},
+			"param \"status\": invalid status value \"lost\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LocalizeByID(tt.messageID, "en", tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	if err := ValidateParams("Hello", map[string]interface{}{}); err != nil {
+		t.Errorf("ValidateParams(Hello) = %v", err)
+	}
+}
+`)
+}
@@ -0,0 +1,36 @@
+package tests
+
+import "testing"
+
+func TestLocalizeWith(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません: {{.name}}"
+  en: "{{.entity}} not found: {{.name}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: ユーザー
+  en: User
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestLocalizeWith(t *testing.T) {
+	msg := NewEntityNotFound(EntityTexts.User, NewNameValue("alice"))
+	if got, want := msg.LocalizeWith("en", map[string]string{"name": "bob"}), "User not found: bob"; got != want {
+		t.Errorf("LocalizeWith(en) = %q, want %q", got, want)
+	}
+	if got, want := msg.LocalizeWith("ja", map[string]string{"entity": "グループ"}), "グループが見つかりません: alice"; got != want {
+		t.Errorf("LocalizeWith(ja) = %q, want %q", got, want)
+	}
+	if got, want := msg.Localize("en"), "User not found: alice"; got != want {
+		t.Errorf("Localize(en) = %q, want %q, overrides must not change the message", got, want)
+	}
+}
+`)
+}
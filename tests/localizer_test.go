@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedLocalizer(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	t.Run("not generated by default", func(t *testing.T) {
+		dir := generatePackage(t, files, nil)
+		code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "type Localizer struct")
+	})
+
+	t.Run("localizes any message in its locale", func(t *testing.T) {
+		dir := generatePackage(t, files, func(cfg *config.Config) {
+			cfg.Localizer = true
+		})
+
+		runPackageTest(t, dir, `package generated
+
+import "testing"
+
+type notifier struct {
+	localizer Localizer
+}
+
+func (n notifier) notFound() string {
+	return n.localizer.Localize(NewEntityNotFound(EntityTexts.User))
+}
+
+func TestLocalizer(t *testing.T) {
+	if got := (notifier{localizer: NewLocalizer("en")}).notFound(); got != "User not found" {
+		t.Fatalf("en: got %q", got)
+	}
+	if got := (notifier{localizer: NewLocalizer("ja")}).notFound(); got != "ユーザーが見つかりません" {
+		t.Fatalf("ja: got %q", got)
+	}
+	if got := NewLocalizer("ja").Localize(EntityTexts.User); got != "ユーザー" {
+		t.Fatalf("placeholder: got %q", got)
+	}
+	if got := NewLocalizer("en").Locale(); got != "en" {
+		t.Fatalf("locale: got %q", got)
+	}
+}
+`)
+	})
+}
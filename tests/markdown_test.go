@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownMessages(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Welcome:
+  markdown: true
+  ja: "**{{.name}}**さん、[ヘルプ](https://example.com/help)をご覧ください（{{.topic}}）"
+  en: "Welcome, **{{.name}}**! See the [help](https://example.com/help) or _{{.topic}}_."
+Plain:
+  ja: "**{{.name}}**"
+  en: "**{{.name}}**"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestMarkdownMessages(t *testing.T) {
+	tests := []struct {
+		name, topic, locale, want string
+	}{
+		{
+			"Ann", "billing", "en",
+			"Welcome, <strong>Ann</strong>! See the <a href=\"https://example.com/help\">help</a> or <em>billing</em>.",
+		},
+		{
+			"杏", "", "ja",
+			"<strong>杏</strong>さん、<a href=\"https://example.com/help\">ヘルプ</a>をご覧ください（）",
+		},
+		// Placeholder values are neither markdown nor HTML
+		{
+			"<b>*Ann*</b>", "[x](javascript:alert(1))", "en",
+			"Welcome, <strong>&lt;b&gt;*Ann*&lt;/b&gt;</strong>! See the <a href=\"https://example.com/help\">help</a> or <em>[x](javascript:alert(1))</em>.",
+		},
+	}
+	for _, tt := range tests {
+		got := NewWelcome(NewNameValue(tt.name), NewTopicValue(tt.topic)).Localize(tt.locale)
+		if got != tt.want {
+			t.Errorf("Localize(%q) with %q:\n got %q\nwant %q", tt.locale, tt.name, got, tt.want)
+		}
+	}
+
+	// Messages without the flag are left as-is
+	if got := NewPlain(NewNameValue("<b>")).Localize("en"); got != "**<b>**" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"a < b & c":                    "a &lt; b &amp; c",
+		"*one* and _two_":              "<em>one</em> and <em>two</em>",
+		"**bold _nested_**":            "<strong>bold <em>nested</em></strong>",
+		"unclosed **bold":              "unclosed **bold",
+		"[x](javascript:alert(1))":     "[x](javascript:alert(1))",
+		"[x](/relative?a=1&b=2)":       "<a href=\"/relative?a=1&amp;b=2\">x</a>",
+		"[mail](mailto:a@example.com)": "<a href=\"mailto:a@example.com\">mail</a>",
+		"\\*literal\\*":                "*literal*",
+	}
+	for in, want := range tests {
+		if got := renderMarkdown(in); got != want {
+			t.Errorf("renderMarkdown(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+`)
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), `"html"`)
+}
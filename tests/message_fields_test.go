@@ -0,0 +1,38 @@
+package tests
+
+import "testing"
+
+func TestMessageFields(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません: {{.reason}}"
+  en: "{{.entity}} not found: {{.reason}}"
+Hello:
+  ja: "こんにちは"
+  en: "Hello"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: ユーザー
+  en: User
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMessageFields(t *testing.T) {
+	if got, want := MessageFields["EntityNotFound"], []string{"entity", "reason"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MessageFields[EntityNotFound] = %v, want %v", got, want)
+	}
+	if got, ok := MessageFields["Hello"]; !ok || len(got) != 0 {
+		t.Errorf("MessageFields[Hello] = %v, %v, want an empty list", got, ok)
+	}
+}
+`)
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestMessageIDMethods(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+Labeled:
+  ja: "{{.string}}"
+  en: "{{.string}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMessageIDMethods(t *testing.T) {
+	msg := NewEntityNotFound(EntityTexts.User)
+	if got := msg.MessageID(); got != "EntityNotFound" {
+		t.Errorf("MessageID() = %q", got)
+	}
+	if got := fmt.Sprintf("%v", msg); got != "EntityNotFound" {
+		t.Errorf("%%v = %q", got)
+	}
+
+	// A {{.string}} placeholder does not clash with the String method
+	labeled := NewLabeled(NewStringValue("x"))
+	if got := labeled.StringField.Value; got != "x" {
+		t.Errorf("StringField = %q", got)
+	}
+	if got := labeled.String(); got != "Labeled" {
+		t.Errorf("String() = %q", got)
+	}
+}
+`)
+}
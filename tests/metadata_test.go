@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageMetadata(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `TemplateSyntax:
+  ja: "値は {{ と }} で囲みます"
+  en: "Wrap values in {{ and }}"
+  metadata:
+    ja:
+      leftDelim: "<<"
+      rightDelim: ">>"
+    en:
+      leftDelim: "<<"
+      rightDelim: ">>"
+      description: "Shown in the template editor help"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), `leftDelim: "<<"`)
+	assert.NotContains(t, string(code), "TemplateSyntaxMetadata", "metadata does not change the generated API")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestMessageMetadata(t *testing.T) {
+	if got := NewTemplateSyntax().Localize("en"); got != "Wrap values in {{ and }}" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewTemplateSyntax().Localize("ja"); got != "値は {{ と }} で囲みます" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestNoPlural(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `ItemCount:
+  no_plural: [fr]
+  ja: "{{.Count}}件"
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+  fr: "{{.Count}} article(s)"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.Locales = []string{"ja", "en", "fr"}
+		// no_plural completes fr, so strict mode accepts the message
+		cfg.Strict = true
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestNoPlural(t *testing.T) {
+	for _, tt := range []struct {
+		locale string
+		count  int
+		want   string
+	}{
+		{"en", 1, "1 item"},
+		{"en", 2, "2 items"},
+		{"fr", 1, "1 article(s)"},
+		{"fr", 2, "2 article(s)"},
+		{"ja", 1, "1件"},
+	} {
+		if got := NewItemCount().WithPluralCount(tt.count).Localize(tt.locale); got != tt.want {
+			t.Errorf("Localize(%q, %d) = %q, want %q", tt.locale, tt.count, got, tt.want)
+		}
+	}
+}
+`)
+}
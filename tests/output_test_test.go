@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+	"github.com/hacomono-lib/go-i18ngen/internal/templatex"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputTest(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+OrderStatus:
+  ja: "注文 {{.order}} は {{.status}} です"
+  en: "Order {{.order}} is {{.status}}"
+ItemCount:
+  ja: "{{.Count}}件"
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.OutputTest = true
+		cfg.EnumPlaceholders = map[string][]string{"status": {"pending", "done"}}
+	})
+
+	smokeTest, err := os.ReadFile(filepath.Join(dir, templatex.SmokeTestFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(smokeTest), `{"EntityNotFound", NewEntityNotFound(EntityTexts.User)},`)
+	assert.Contains(t, string(smokeTest), `{"ItemCount", NewItemCount().WithPluralCount(2)},`)
+	assert.Contains(t, string(smokeTest), "func TestGeneratedMessages(t *testing.T)")
+	assert.Contains(t, string(smokeTest), `Localize("ja")`)
+
+	// The package tests include the generated smoke test
+	runPackageTest(t, dir, "package generated\n")
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsConstructor(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityMoved:
+  ja: "{{.actor}}が{{.entity:from}}から{{.entity:to}}に移動しました"
+  en: "{{.actor}} moved from {{.entity:from}} to {{.entity:to}}"
+Greeting:
+  ja: "こんにちは {{.actor}}"
+  en: "Hello {{.actor}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+group:
+  ja: "グループ"
+  en: "Group"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.ParamsMinFields = 3
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "func NewEntityMovedFromParams(params EntityMovedParams) EntityMoved")
+	assert.Contains(t, string(code), "func NewEntityMoved(actor ActorValue, entityFrom EntityText, entityTo EntityText) EntityMoved")
+	assert.NotContains(t, string(code), "GreetingParams")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestParamsConstructor(t *testing.T) {
+	msg := NewEntityMovedFromParams(EntityMovedParams{
+		Actor:      NewActorValue("Alice"),
+		EntityFrom: EntityTexts.User,
+		EntityTo:   EntityTexts.Group,
+	})
+	if got := msg.Localize("en"); got != "Alice moved from User to Group" {
+		t.Errorf("got %q", got)
+	}
+	positional := NewEntityMoved(NewActorValue("Alice"), EntityTexts.User, EntityTexts.Group)
+	if got := positional.Localize("ja"); got != msg.Localize("ja") {
+		t.Errorf("got %q, want %q", got, msg.Localize("ja"))
+	}
+}
+`)
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceholderConsts(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+product_item:
+  ja: "製品"
+  en: "Product"
+`,
+	}
+
+	t.Run("not generated by default", func(t *testing.T) {
+		dir := generatePackage(t, files, nil)
+		code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(code), "type EntityID string")
+	})
+
+	t.Run("typed constants keyed on source IDs", func(t *testing.T) {
+		dir := generatePackage(t, files, func(cfg *config.Config) {
+			cfg.PlaceholderConsts = true
+		})
+
+		runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestPlaceholderConsts(t *testing.T) {
+	var id EntityID = EntityProductItem
+	if id != "product_item" || EntityUser != "user" {
+		t.Errorf("unexpected constants %q, %q", id, EntityUser)
+	}
+	// The constants match the IDs of the accessor instances
+	if EntityID(EntityTexts.User.ID()) != EntityUser {
+		t.Errorf("got %q", EntityTexts.User.ID())
+	}
+	switch EntityID(EntityTexts.ProductItem.ID()) {
+	case EntityProductItem:
+	default:
+		t.Error("switch on EntityID did not match")
+	}
+}
+`)
+	})
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestPlaceholderIDs(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+StatusChanged:
+  ja: "{{.status}}に変更しました"
+  en: "Changed to {{.status}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+product_item:
+  ja: "製品"
+  en: "Product"
+account:
+  ja: "アカウント"
+  en: "Account"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.EnumPlaceholders = map[string][]string{"status": {"pending", "done"}}
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholderIDs(t *testing.T) {
+	want := []string{"account", "product_item", "user"}
+	ids := EntityIDs()
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("EntityIDs() = %v, want %v", ids, want)
+	}
+	// Every ID round-trips through the constructor
+	for _, id := range ids {
+		if got := NewEntityText(id).ID(); got != id {
+			t.Errorf("NewEntityText(%q).ID() = %q", id, got)
+		}
+	}
+	// Callers get their own copy
+	ids[0] = "changed"
+	if EntityIDs()[0] != "account" {
+		t.Error("EntityIDs returned a shared slice")
+	}
+
+	if got := StatusIDs(); !reflect.DeepEqual(got, []string{"done", "pending"}) {
+		t.Errorf("StatusIDs() = %v", got)
+	}
+
+	if got := PlaceholderKinds(); !reflect.DeepEqual(got, []string{"Entity", "Status"}) {
+		t.Errorf("PlaceholderKinds() = %v", got)
+	}
+}
+`)
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestPlaceholderReferences(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません"
+  en: "{{.entity}} not found"
+`,
+		"placeholders/entity.yaml": `user_account:
+  ja: "ユーザーアカウント"
+  en: "user account"
+deleted_user_account:
+  ja: "{{.deleted}}{{.user_account}}"
+  en: "{{.deleted}} {{.user_account}}"
+looping:
+  ja: "{{.looping}}"
+  en: "see {{.back}}"
+back:
+  ja: "戻る"
+  en: "back to {{.looping}}"
+`,
+		"placeholders/state.yaml": `deleted:
+  ja: "削除済み"
+  en: "deleted"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestPlaceholderReferences(t *testing.T) {
+	msg := NewEntityNotFound(EntityTexts.DeletedUserAccount)
+	if got := msg.Localize("en"); got != "deleted user account not found" {
+		t.Errorf("en: got %q", got)
+	}
+	if got := msg.Localize("ja"); got != "削除済みユーザーアカウントが見つかりません" {
+		t.Errorf("ja: got %q", got)
+	}
+
+	// Cycles stop at the first repeated reference instead of looping forever
+	if got := EntityTexts.Looping.Localize("en"); got != "see back to {{.looping}}" {
+		t.Errorf("cycle: got %q", got)
+	}
+	if got := EntityTexts.Looping.Localize("ja"); got != "{{.looping}}" {
+		t.Errorf("self reference: got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlainValueStyle(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `UserAlreadyExists:
+  ja: "{{.entity}}はすでに存在します: {{.user_id}}"
+  en: "{{.entity}} already exists: {{.user_id}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.ValueStyle = config.ValueStylePlain
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "func NewUserAlreadyExists(entity EntityText, userId string) UserAlreadyExists")
+	assert.NotContains(t, string(code), "UserIdValue")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestPlainValueStyle(t *testing.T) {
+	msg := NewUserAlreadyExists(EntityTexts.User, "user123")
+	if got := msg.Localize("en"); got != "User already exists: user123" {
+		t.Errorf("got %q", got)
+	}
+	if got := msg.Localize("ja"); got != "ユーザーはすでに存在します: user123" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
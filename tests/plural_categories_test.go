@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllPluralCategories(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `AppleCount:
+  en:
+    one: "{{.Count}} apple"
+    other: "{{.Count}} apples"
+  ar:
+    other: "{{.Count}} تفاحة (other)"
+    many: "{{.Count}} تفاحة (many)"
+    few: "{{.Count}} تفاحات"
+    two: "تفاحتان"
+    one: "تفاحة واحدة"
+    zero: "لا تفاح"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.Locales = []string{"en", "ar"}
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+
+	// Every CLDR category is embedded in go-i18n's plural YAML, in CLDR order
+	assert.Contains(t, string(code), `AppleCount:
+  zero: "لا تفاح"
+  one: "تفاحة واحدة"
+  two: "تفاحتان"
+  few: "{{.Count}} تفاحات"
+  many: "{{.Count}} تفاحة (many)"
+  other: "{{.Count}} تفاحة (other)"`)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestArabicPluralForms(t *testing.T) {
+	expected := map[int]string{
+		0:   "لا تفاح",
+		1:   "تفاحة واحدة",
+		2:   "تفاحتان",
+		3:   "3 تفاحات",
+		11:  "11 تفاحة (many)",
+		100: "100 تفاحة (other)",
+	}
+	for count, want := range expected {
+		if got := NewAppleCount().WithPluralCount(count).Localize("ar"); got != want {
+			t.Errorf("count %d: got %q, want %q", count, got, want)
+		}
+	}
+	if got := NewAppleCount().WithPluralCount(1).Localize("en"); got != "1 apple" {
+		t.Errorf("en: got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluralPlaceholders(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `ItemsLeft:
+  ja: "残り{{.Count}}{{.unit}}"
+  en:
+    one: "{{.Count}} {{.unit}} left"
+    other: "{{.Count}} {{.unit}} left"
+UnitLabel:
+  ja: "単位: {{.unit}}"
+  en: "Unit: {{.unit}}"
+`,
+		"placeholders/unit.yaml": `item:
+  ja: "個"
+  en:
+    one: "item"
+    other: "items"
+box:
+  ja: "箱"
+  en: "box"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "func (p UnitText) LocalizeCount(locale string, count int) string")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestPluralPlaceholders(t *testing.T) {
+	tests := []struct {
+		msg      Localizable
+		locale   string
+		expected string
+	}{
+		{NewItemsLeft(UnitTexts.Item).WithPluralCount(1), "en", "1 item left"},
+		{NewItemsLeft(UnitTexts.Item).WithPluralCount(3), "en", "3 items left"},
+		{NewItemsLeft(UnitTexts.Item).WithPluralCount(3), "ja", "残り3個"},
+		// Items without plural forms keep their text regardless of the count
+		{NewItemsLeft(UnitTexts.Box).WithPluralCount(3), "en", "3 box left"},
+		// Without a count the "other" form is used
+		{NewUnitLabel(UnitTexts.Item), "en", "Unit: items"},
+	}
+	for _, tt := range tests {
+		if got := tt.msg.Localize(tt.locale); got != tt.expected {
+			t.Errorf("got %q, want %q", got, tt.expected)
+		}
+	}
+
+	if got := UnitTexts.Item.LocalizeCount("en", 1); got != "item" {
+		t.Errorf("LocalizeCount: got %q", got)
+	}
+}
+`)
+}
@@ -347,3 +347,40 @@ dummy:
 
 	t.Logf("Pluralization parser test passed")
 }
+
+func TestPluralMessageDataEmbedding(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `ItemCount:
+  ja: "{{.Count}}個のアイテム"
+  en:
+    one: "{{.Count}} item"
+    other: "{{.Count}} items"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated code: %v", err)
+	}
+	// The plural block is embedded as go-i18n YAML with every form
+	expected := "ItemCount:\n  one: \"{{.Count}} item\"\n  other: \"{{.Count}} items\"\n"
+	if !strings.Contains(string(code), expected) {
+		t.Errorf("messageData should contain the plural block %q", expected)
+	}
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestPluralMessageData(t *testing.T) {
+	if got := NewItemCount().WithPluralCount(1).Localize("en"); got != "1 item" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewItemCount().WithPluralCount(2).Localize("en"); got != "2 items" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestRegionFallback(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Hello:
+  ja: "こんにちは"
+  en: "Hello"
+  en-GB: "Hello, mate"
+  pt: "Olá"
+Bye:
+  ja: "さようなら {{.entity}}"
+  en: "Bye {{.entity}}"
+  pt: "Tchau {{.entity}}"
+Thanks:
+  ja: "ありがとう"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+  en-GB: "Member"
+  pt: "Usuário"
+group:
+  ja: "グループ"
+  en: "Group"
+  pt: "Grupo"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.Locales = []string{"ja", "en", "en-GB", "pt"}
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestRegionFallback(t *testing.T) {
+	tests := []struct {
+		locale string
+		msg    Localizable
+		want   string
+	}{
+		{"en-GB", NewHello(), "Hello, mate"},
+		// Bye has no en-GB template: the base language is used before the primary locale
+		{"en-GB", NewBye(EntityTexts.User), "Bye Member"},
+		{"en-GB", NewBye(EntityTexts.Group), "Bye Group"},
+		{"pt-BR", NewBye(EntityTexts.Group), "Tchau Grupo"},
+		// Neither en-GB nor en has Thanks, so the primary locale is used
+		{"en-GB", NewThanks(), "ありがとう"},
+	}
+	for _, tt := range tests {
+		if got := tt.msg.Localize(tt.locale); got != tt.want {
+			t.Errorf("Localize(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+`)
+}
@@ -134,5 +134,18 @@ func TestDirectGoI18nRuntime(t *testing.T) {
 		require.Equal(t, "Product", resultEn, "Entity should localize correctly in English")
 	})
 
+	t.Run("PlaceholderIDMatchesSourceKey", func(t *testing.T) {
+		// IDs are the original source keys, not the CamelCased Go field names
+		items := map[string]Localizable{
+			"user":            EntityTexts.User,
+			"product":         EntityTexts.Product,
+			"already_deleted": ReasonTexts.AlreadyDeleted,
+		}
+		for expected, item := range items {
+			require.Equal(t, expected, item.ID())
+		}
+		require.Equal(t, "already_deleted", NewReasonText("already_deleted").ID())
+	})
+
 	t.Log("✅ Direct go-i18n runtime test passed successfully!")
 }
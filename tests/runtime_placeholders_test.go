@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimePlaceholders(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Welcome:
+  ja: "{{.appName}}へようこそ、{{.name}}さん"
+  en: "Welcome to {{.appName}}, {{.name}}"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.RuntimePlaceholders = map[string]config.RuntimePlaceholder{
+			"appName": {Runtime: true},
+		}
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "var AppNameProvider func(locale string) string")
+	assert.Contains(t, string(code), "func NewWelcome(name NameValue) Welcome", "runtime placeholders are not constructor arguments")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestRuntimePlaceholders(t *testing.T) {
+	msg := NewWelcome(NewNameValue("Alice"))
+	if got := msg.Localize("en"); got != "Welcome to , Alice" {
+		t.Errorf("unset provider: got %q", got)
+	}
+
+	AppNameProvider = func(locale string) string {
+		if locale == "ja" {
+			return "アプリ"
+		}
+		return "App"
+	}
+	defer func() { AppNameProvider = nil }()
+
+	if got := msg.Localize("en"); got != "Welcome to App, Alice" {
+		t.Errorf("got %q", got)
+	}
+	if got := msg.Localize("ja"); got != "アプリへようこそ、Aliceさん" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
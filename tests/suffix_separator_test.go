@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuffixSeparator(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityMoved:
+  ja: "{{.entity__from}}から{{.entity__to}}に移動しました"
+  en: "Moved from {{.entity__from}} to {{.entity__to}}"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+group:
+  ja: "グループ"
+  en: "Group"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		cfg.SuffixSeparator = "__"
+	})
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "func NewEntityMoved(entityFrom EntityText, entityTo EntityText) EntityMoved")
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestSuffixSeparator(t *testing.T) {
+	msg := NewEntityMoved(EntityTexts.User, EntityTexts.Group)
+	if got := msg.Localize("en"); got != "Moved from User to Group" {
+		t.Errorf("got %q", got)
+	}
+	if got := msg.Localize("ja"); got != "ユーザーからグループに移動しました" {
+		t.Errorf("got %q", got)
+	}
+}
+`)
+}
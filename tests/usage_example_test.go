@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageExample(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `EntityNotFound:
+  ja: "{{.entity}}が見つかりません: {{.reason}}"
+  en: "{{.entity}} not found: {{.reason}}"
+Welcome:
+  ja: "{{.name:user}}さん、ようこそ"
+  en: "Welcome {{.name:user}}"
+UserCount:
+  ja: "{{.Count}}人のユーザー"
+  en:
+    one: "{{.Count}} user"
+    other: "{{.Count}} users"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	t.Run("not generated without a package path", func(t *testing.T) {
+		dir := generatePackage(t, files, nil)
+		assert.NoDirExists(t, filepath.Join(dir, "example"))
+	})
+
+	t.Run("demonstrates every constructor", func(t *testing.T) {
+		dir := generatePackage(t, files, func(cfg *config.Config) {
+			cfg.PackagePath = "github.com/hacomono-lib/go-i18ngen/tests/" + filepath.Base(cfg.OutputDir)
+		})
+
+		code, err := os.ReadFile(filepath.Join(dir, "example", "usage_example.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), `generated "github.com/hacomono-lib/go-i18ngen/tests/`+filepath.Base(dir)+`"`)
+		assert.Contains(t, string(code), `generated.NewEntityNotFound(generated.EntityTexts.User, generated.NewReasonValue("reason")).Localize(locale)`)
+		assert.Contains(t, string(code), `generated.NewWelcome(generated.NewNameValue("nameUser")).Localize(locale)`)
+		assert.Contains(t, string(code), `generated.NewUserCount().WithPluralCount(2).Localize(locale)`)
+
+		runPackageTest(t, filepath.Join(dir, "example"), `package example
+
+import "testing"
+
+func TestRun(t *testing.T) {
+	Run()
+}
+`)
+	})
+}
@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hacomono-lib/go-i18ngen/internal/config"
+)
+
+func TestValueSuffix(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Greeting:
+  ja: "こんにちは、{{.name}}さん"
+  en: "Hello, {{.name}}"
+`,
+	}
+
+	dir := generatePackage(t, files, func(cfg *config.Config) {
+		suffix := "Arg"
+		cfg.ValueSuffix = &suffix
+	})
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestValueSuffix(t *testing.T) {
+	var name NameArg = NewNameArg("Ann")
+	if got, want := NewGreeting(name).Localize("en"), "Hello, Ann"; got != want {
+		t.Errorf("Localize(en) = %q, want %q", got, want)
+	}
+}
+`)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageVariants(t *testing.T) {
+	files := map[string]string{
+		"messages/messages.yaml": `Greeting:
+  ja: "こんにちは、{{.name}}さん"
+  en: "Hello, {{.name}}"
+  variants:
+    casual:
+      ja: "やあ、{{.name}}"
+      en: "Hey {{.name}}!"
+    formal:
+      en: "Good day, {{.name}}"
+CartItems:
+  ja: "{{.Count}}個の商品"
+  en:
+    one: "{{.Count}} item in your cart"
+    other: "{{.Count}} items in your cart"
+  variants:
+    short:
+      en:
+        one: "{{.Count}} item"
+        other: "{{.Count}} items"
+`,
+		"placeholders/entity.yaml": `user:
+  ja: "ユーザー"
+  en: "User"
+`,
+	}
+
+	dir := generatePackage(t, files, nil)
+
+	code, err := os.ReadFile(filepath.Join(dir, "i18n.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), `//   - "casual"`)
+
+	runPackageTest(t, dir, `package generated
+
+import "testing"
+
+func TestMessageVariants(t *testing.T) {
+	name := NewNameValue("Alice")
+	cases := []struct {
+		msg    Greeting
+		locale string
+		want   string
+	}{
+		{NewGreeting(name), "en", "Hello, Alice"},
+		{NewGreeting(name).WithVariant("casual"), "en", "Hey Alice!"},
+		{NewGreeting(name).WithVariant("casual"), "ja", "やあ、Alice"},
+		{NewGreeting(name).WithVariant("formal"), "en", "Good day, Alice"},
+		// Untranslated variants render the default text of the locale
+		{NewGreeting(name).WithVariant("formal"), "ja", "こんにちは、Aliceさん"},
+		{NewGreeting(name).WithVariant(""), "en", "Hello, Alice"},
+	}
+	for _, c := range cases {
+		if got := c.msg.Localize(c.locale); got != c.want {
+			t.Errorf("got %q, want %q", got, c.want)
+		}
+	}
+
+	if got := NewCartItems().WithVariant("short").WithPluralCount(1).Localize("en"); got != "1 item" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewCartItems().WithVariant("short").WithPluralCount(3).Localize("en"); got != "3 items" {
+		t.Errorf("got %q", got)
+	}
+
+	var unknown []string
+	UnknownVariantHook = func(messageID, variant string) { unknown = append(unknown, messageID+"/"+variant) }
+	defer func() { UnknownVariantHook = nil }()
+	if got := NewGreeting(name).WithVariant("missing").Localize("en"); got != "Hello, Alice" {
+		t.Errorf("got %q", got)
+	}
+	if len(unknown) != 1 || unknown[0] != "Greeting/missing" {
+		t.Errorf("hook calls: %v", unknown)
+	}
+}
+`)
+}